@@ -0,0 +1,47 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package automation_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/repejota/git-hub/automation"
+)
+
+func TestEditText(t *testing.T) {
+	defer os.Setenv("VISUAL", os.Getenv("VISUAL"))
+	os.Setenv("VISUAL", "sed -i -e s/draft/final/")
+	text, err := automation.EditText("A draft title\n", "git-hub-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "A final title\n"
+	if text != expected {
+		t.Fatalf("Expected text %q but got %q", expected, text)
+	}
+}
+
+func TestEditTextEditorFails(t *testing.T) {
+	defer os.Setenv("VISUAL", os.Getenv("VISUAL"))
+	os.Setenv("VISUAL", "false")
+	_, err := automation.EditText("A draft title\n", "git-hub-test")
+	if err == nil {
+		t.Fatalf("Expected an error when the editor fails")
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 // GetCurrentBranch ...
@@ -65,7 +67,9 @@ func PushLocalBranchToOrigin(name string) (string, error) {
 }
 
 // BumpNextVersion ...
-func BumpNextVersion(nextversion string) (string, error) {
+//
+// Extra files, if any, are included in the same bump commit.
+func BumpNextVersion(nextversion string, files ...string) (string, error) {
 	finalOut := ""
 
 	// update VERSION file contents
@@ -76,14 +80,16 @@ func BumpNextVersion(nextversion string) (string, error) {
 	}
 
 	// commit VERSION changes
-	out, err := exec.Command("git", "add", "VERSION").Output()
+	paths := append([]string{"VERSION"}, files...)
+	out, err := exec.Command("git", append([]string{"add"}, paths...)...).Output()
 	if err != nil {
 		return "", err
 	}
 	finalOut = fmt.Sprintf("%s%s", finalOut, string(out))
 
 	commitMsg := fmt.Sprintf("Bump %s", nextversion)
-	out, err = exec.Command("git", "commit", "VERSION", "-m", commitMsg).Output()
+	args := append([]string{"commit", "-m", commitMsg, "--"}, paths...)
+	out, err = exec.Command("git", args...).Output()
 	if err != nil {
 		return "", err
 	}
@@ -99,6 +105,26 @@ func BumpNextVersion(nextversion string) (string, error) {
 	return finalOut, nil
 }
 
+// CommitFiles commits only the given files
+func CommitFiles(message string, files ...string) (string, error) {
+	finalOut := ""
+
+	out, err := exec.Command("git", append([]string{"add"}, files...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	finalOut = fmt.Sprintf("%s%s", finalOut, string(out))
+
+	args := append([]string{"commit", "-m", message, "--"}, files...)
+	out, err = exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	finalOut = fmt.Sprintf("%s%s", finalOut, string(out))
+
+	return finalOut, nil
+}
+
 // GitPush ...
 func GitPush() (string, error) {
 	out, err := exec.Command("git", "push").Output()
@@ -149,6 +175,17 @@ func CreateGitTag(tagName string) (string, error) {
 	return sout, nil
 }
 
+// CreateGitTagAt creates an annotated tag pointing to a revision
+func CreateGitTagAt(tagName string, revision string) (string, error) {
+	msgTag := fmt.Sprintf("Release %s", tagName)
+	out, err := exec.Command("git", "tag", "-a", tagName, "-m", msgTag, revision).Output()
+	if err != nil {
+		return "", err
+	}
+	sout := string(out)
+	return sout, nil
+}
+
 // GitPushTags ...
 func GitPushTags() (string, error) {
 	out, err := exec.Command("git", "push", "--tags").Output()
@@ -167,6 +204,24 @@ func DeleteRemoteBranch(branchName string) (string, error) {
 	return string(out), nil
 }
 
+// DeleteRemoteTag ...
+func DeleteRemoteTag(tagName string) (string, error) {
+	out, err := exec.Command("git", "push", "origin", "-d", "refs/tags/"+tagName).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// DeleteLocalTag ...
+func DeleteLocalTag(tagName string) (string, error) {
+	out, err := exec.Command("git", "tag", "-d", tagName).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
 // DeleteLocalBranch ...
 func DeleteLocalBranch(branchName string) (string, error) {
 	out, err := exec.Command("git", "branch", "-d", branchName).Output()
@@ -175,3 +230,507 @@ func DeleteLocalBranch(branchName string) (string, error) {
 	}
 	return string(out), nil
 }
+
+// GetConfig returns the value of a git-hub setting from git config, for
+// example "calver" reads "git-hub.calver". Unset settings are returned as an
+// empty string.
+func GetConfig(name string) (string, error) {
+	out, err := exec.Command("git", "config", "--get", "git-hub."+name).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	sout := strings.Trim(string(out), "\n")
+	return sout, nil
+}
+
+// GetPreviousTag returns the closest tag reachable from the parent of a
+// tag, or an empty string if there is none.
+func GetPreviousTag(tagName string) (string, error) {
+	out, err := exec.Command("git", "describe", "--tags", "--abbrev=0", tagName+"^").Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", nil
+		}
+		return "", err
+	}
+	sout := strings.Trim(string(out), "\n")
+	return sout, nil
+}
+
+// GetCommitDate returns the committer date of a revision
+func GetCommitDate(revision string) (time.Time, error) {
+	out, err := exec.Command("git", "log", "-1", "--format=%cI", revision).Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+	sout := strings.Trim(string(out), "\n")
+	return time.Parse(time.RFC3339, sout)
+}
+
+// GetMergeCommitMessages returns the messages of the merge commits between
+// two revisions, if from is empty all the merge commits reachable from to
+// are returned.
+func GetMergeCommitMessages(from string, to string) ([]string, error) {
+	revisionRange := to
+	if from != "" {
+		revisionRange = fmt.Sprintf("%s..%s", from, to)
+	}
+	out, err := exec.Command("git", "log", "--merges", "--format=%B%x00", revisionRange).Output()
+	if err != nil {
+		return nil, err
+	}
+	messages := []string{}
+	for _, message := range strings.Split(string(out), "\x00") {
+		message = strings.TrimSpace(message)
+		if message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// GetCommitSubjects returns the subjects of the non merge commits between
+// two revisions, if from is empty all the commits reachable from to are
+// returned.
+func GetCommitSubjects(from string, to string) ([]string, error) {
+	revisionRange := to
+	if from != "" {
+		revisionRange = fmt.Sprintf("%s..%s", from, to)
+	}
+	out, err := exec.Command("git", "log", "--no-merges", "--format=%s", revisionRange).Output()
+	if err != nil {
+		return nil, err
+	}
+	subjects := []string{}
+	for _, subject := range strings.Split(string(out), "\n") {
+		if subject != "" {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects, nil
+}
+
+// GetCommitHashes returns the hashes of the commits between two revisions,
+// if from is empty all the commits reachable from to are returned.
+func GetCommitHashes(from string, to string) ([]string, error) {
+	revisionRange := to
+	if from != "" {
+		revisionRange = fmt.Sprintf("%s..%s", from, to)
+	}
+	out, err := exec.Command("git", "rev-list", revisionRange).Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// FetchOrigin ...
+func FetchOrigin() (string, error) {
+	out, err := exec.Command("git", "fetch", "--prune", "origin").Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ListLocalBranches returns the names of the local branches
+func ListLocalBranches() ([]string, error) {
+	return listRefs("refs/heads/", "")
+}
+
+// ListRemoteBranches returns the names of the branches on origin, without
+// the remote prefix.
+func ListRemoteBranches() ([]string, error) {
+	return listRefs("refs/remotes/origin/", "origin/")
+}
+
+// listRefs returns the short names of the refs under a prefix, with
+// trimPrefix removed.
+func listRefs(refPrefix string, trimPrefix string) ([]string, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname:short)", refPrefix).Output()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, name := range strings.Split(string(out), "\n") {
+		name = strings.TrimPrefix(name, trimPrefix)
+		if name != "" && name != "HEAD" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// CheckoutRemoteBranch creates a local branch tracking the branch with the
+// same name on origin.
+func CheckoutRemoteBranch(name string) (string, error) {
+	out, err := exec.Command("git", "checkout", "-b", name, "--track", "origin/"+name).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// SetUpstreamBranch sets the branch with the same name on origin as the
+// upstream of a local branch.
+func SetUpstreamBranch(name string) (string, error) {
+	out, err := exec.Command("git", "branch", "--set-upstream-to=origin/"+name, name).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// GetAheadBehind returns the number of commits a revision is ahead and
+// behind of a base revision.
+func GetAheadBehind(base string, revision string) (int, int, error) {
+	out, err := exec.Command("git", "rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", base, revision)).Output()
+	if err != nil {
+		return 0, 0, err
+	}
+	var behind, ahead int
+	_, err = fmt.Sscan(string(out), &behind, &ahead)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
+// GetUncommittedChanges returns the short status lines of the uncommitted
+// changes of the working tree.
+func GetUncommittedChanges() ([]string, error) {
+	out, err := exec.Command("git", "status", "--porcelain").Output()
+	if err != nil {
+		return nil, err
+	}
+	changes := []string{}
+	for _, change := range strings.Split(string(out), "\n") {
+		if change != "" {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// FetchPullRequest fetches the head of a pull request from origin, into a
+// new local branch if a name is given or into FETCH_HEAD otherwise.
+func FetchPullRequest(number int, branchName string) (string, error) {
+	refSpec := fmt.Sprintf("pull/%d/head", number)
+	if branchName != "" {
+		refSpec = fmt.Sprintf("%s:%s", refSpec, branchName)
+	}
+	out, err := exec.Command("git", "fetch", "origin", refSpec).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// MergeFastForward fast forwards the current branch to a revision
+func MergeFastForward(revision string) (string, error) {
+	out, err := exec.Command("git", "merge", "--ff-only", revision).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// GetRemoteURL returns the URL of a remote, or an empty string if there is
+// no such remote.
+func GetRemoteURL(name string) (string, error) {
+	out, err := exec.Command("git", "config", "--get", fmt.Sprintf("remote.%s.url", name)).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.Trim(string(out), "\n"), nil
+}
+
+// AddRemote ...
+func AddRemote(name string, url string) (string, error) {
+	out, err := exec.Command("git", "remote", "add", "-f", name, url).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// SetBranchUpstreamRef sets the remote and the remote ref a local branch
+// pulls from and pushes to, the ref does not need to be fetched.
+func SetBranchUpstreamRef(branchName string, remote string, mergeRef string) error {
+	err := exec.Command("git", "config", fmt.Sprintf("branch.%s.remote", branchName), remote).Run()
+	if err != nil {
+		return err
+	}
+	return exec.Command("git", "config", fmt.Sprintf("branch.%s.merge", branchName), mergeRef).Run()
+}
+
+// ForceDeleteLocalBranch deletes a local branch even if it is not merged,
+// as branches squashed or rebased on GitHub are not.
+func ForceDeleteLocalBranch(branchName string) (string, error) {
+	out, err := exec.Command("git", "branch", "-D", branchName).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// FastForwardBranch fast forwards a local branch to its origin branch, the
+// current branch is pulled and any other one is updated without checking
+// it out.
+func FastForwardBranch(branchName string) (string, error) {
+	currentBranchName, err := GetCurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	args := []string{"fetch", "origin", fmt.Sprintf("%s:%s", branchName, branchName)}
+	if currentBranchName == branchName {
+		args = []string{"pull", "--ff-only", "origin", branchName}
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// SquashBranch merges the changes of a branch into the current branch as a
+// single commit with the given message.
+func SquashBranch(branchName string, message string) (string, error) {
+	finalOut := ""
+
+	out, err := exec.Command("git", "merge", "--squash", branchName).Output()
+	if err != nil {
+		return "", err
+	}
+	finalOut = fmt.Sprintf("%s%s", finalOut, string(out))
+
+	out, err = exec.Command("git", "commit", "-m", message).Output()
+	if err != nil {
+		return "", err
+	}
+	finalOut = fmt.Sprintf("%s%s", finalOut, string(out))
+
+	return finalOut, nil
+}
+
+// RebaseBranch rebases a branch onto another one, leaving the rebased
+// branch checked out.
+func RebaseBranch(branchName string, onto string) (string, error) {
+	out, err := exec.Command("git", "rebase", onto, branchName).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// StashChanges stashes the uncommitted changes, untracked files included,
+// and returns false if there was nothing to stash.
+func StashChanges(message string) (bool, error) {
+	changes, err := GetUncommittedChanges()
+	if err != nil {
+		return false, err
+	}
+	if len(changes) == 0 {
+		return false, nil
+	}
+	err = exec.Command("git", "stash", "push", "--include-untracked", "-m", message).Run()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PopStash applies and drops the latest stash.
+func PopStash() (string, error) {
+	out, err := exec.Command("git", "stash", "pop").Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// MergeRevision merges a revision into the current branch, fast forwarding
+// when possible.
+func MergeRevision(revision string) (string, error) {
+	out, err := exec.Command("git", "merge", "--no-edit", revision).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// HasConflicts returns true if there are unmerged files, left by a merge or
+// a rebase that stopped on conflicts.
+func HasConflicts() (bool, error) {
+	out, err := exec.Command("git", "diff", "--name-only", "--diff-filter=U").Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) != "", nil
+}
+
+// AbortMerge aborts a conflicted merge.
+func AbortMerge() error {
+	return exec.Command("git", "merge", "--abort").Run()
+}
+
+// AbortRebase aborts a conflicted rebase.
+func AbortRebase() error {
+	return exec.Command("git", "rebase", "--abort").Run()
+}
+
+// GetBranchParents returns the parents of the stacked branches, recorded in
+// the branch.<name>.git-hub-parent settings.
+func GetBranchParents() (map[string]string, error) {
+	parents := map[string]string{}
+	out, err := exec.Command("git", "config", "--get-regexp", `^branch\..*\.git-hub-parent$`).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return parents, nil
+		}
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		branchName := strings.TrimSuffix(strings.TrimPrefix(fields[0], "branch."), ".git-hub-parent")
+		parents[branchName] = fields[1]
+	}
+	return parents, nil
+}
+
+// SetBranchParent records the parent of a stacked branch.
+func SetBranchParent(branchName string, parentBranchName string) error {
+	return exec.Command("git", "config", fmt.Sprintf("branch.%s.git-hub-parent", branchName), parentBranchName).Run()
+}
+
+// GetRevision returns the commit hash of a revision.
+func GetRevision(revision string) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", revision+"^{commit}").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RebaseBranchOnto moves the commits of a branch that are not in upstream
+// onto another revision, leaving the rebased branch checked out.
+func RebaseBranchOnto(branchName string, onto string, upstream string) (string, error) {
+	out, err := exec.Command("git", "rebase", "--onto", onto, upstream, branchName).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ForcePushBranch pushes a rewritten branch to origin, unless the origin
+// branch has commits that were not fetched.
+func ForcePushBranch(name string) (string, error) {
+	out, err := exec.Command("git", "push", "--force-with-lease", "--set-upstream", "origin", name).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// CommitEmpty creates a commit without changes.
+func CommitEmpty(message string) (string, error) {
+	out, err := exec.Command("git", "commit", "--allow-empty", "-m", message).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Worktree is a working tree of the repository
+type Worktree struct {
+	Path string
+	Head string
+	// Branch is empty for detached working trees
+	Branch string
+	Bare   bool
+}
+
+// ParseWorktrees parses the output of git worktree list --porcelain.
+func ParseWorktrees(porcelain string) []*Worktree {
+	worktrees := []*Worktree{}
+	var worktree *Worktree
+	for _, line := range strings.Split(porcelain, "\n") {
+		fields := strings.SplitN(line, " ", 2)
+		value := ""
+		if len(fields) == 2 {
+			value = fields[1]
+		}
+		switch fields[0] {
+		case "worktree":
+			worktree = &Worktree{Path: value}
+			worktrees = append(worktrees, worktree)
+		case "HEAD":
+			if worktree != nil {
+				worktree.Head = value
+			}
+		case "branch":
+			if worktree != nil {
+				worktree.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "bare":
+			if worktree != nil {
+				worktree.Bare = true
+			}
+		}
+	}
+	return worktrees
+}
+
+// ListWorktrees returns the working trees of the repository, the main one
+// first.
+func ListWorktrees() ([]*Worktree, error) {
+	out, err := exec.Command("git", "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return nil, err
+	}
+	return ParseWorktrees(string(out)), nil
+}
+
+// AddWorktree adds a working tree for a branch. With a start point a new
+// branch is created from it, otherwise the branch is checked out, or
+// created from the origin branch with the same name.
+func AddWorktree(path string, branchName string, startPoint string) (string, error) {
+	args := []string{"worktree", "add", path, branchName}
+	if startPoint != "" {
+		args = []string{"worktree", "add", "--no-track", "-b", branchName, path, startPoint}
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// RemoveWorktree removes a working tree, unless it has uncommitted changes.
+func RemoveWorktree(path string) (string, error) {
+	out, err := exec.Command("git", "worktree", "remove", path).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// GetCommonDir returns the absolute path of the git directory shared by all
+// the working trees.
+func GetCommonDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return "", err
+	}
+	// It is relative to the current directory unless outside of it
+	return filepath.Abs(strings.TrimSpace(string(out)))
+}
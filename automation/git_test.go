@@ -16,3 +16,25 @@
 // under the License.
 
 package automation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/repejota/git-hub/automation"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	porcelain := "worktree /src/git-hub\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/master\n\n" +
+		"worktree /src/git-hub-worktrees/12-fix-it\nHEAD 2222222222222222222222222222222222222222\nbranch refs/heads/issue/12-fix-it\n\n" +
+		"worktree /src/detached\nHEAD 3333333333333333333333333333333333333333\ndetached\n\n"
+	expected := []*automation.Worktree{
+		{Path: "/src/git-hub", Head: "1111111111111111111111111111111111111111", Branch: "master"},
+		{Path: "/src/git-hub-worktrees/12-fix-it", Head: "2222222222222222222222222222222222222222", Branch: "issue/12-fix-it"},
+		{Path: "/src/detached", Head: "3333333333333333333333333333333333333333"},
+	}
+	worktrees := automation.ParseWorktrees(porcelain)
+	if !reflect.DeepEqual(worktrees, expected) {
+		t.Fatalf("Expected worktrees %+v but got %+v", expected, worktrees)
+	}
+}
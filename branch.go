@@ -0,0 +1,383 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub/automation"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Branch kinds
+const (
+	BranchKindDefault = "default"
+	BranchKindIssue   = "issue"
+	BranchKindFeature = "feature"
+	BranchKindRelease = "release"
+	BranchKindOther   = "other"
+)
+
+// BranchContext is the workflow context encoded on a branch name
+type BranchContext struct {
+	Name string
+	// Kind is one of default, issue, feature, release or other
+	Kind string
+	// Issue is only set for issue branches
+	Issue *IssueBranch
+	// Feature is the feature slug of feature branches
+	Feature string
+	// Version is the version being released on release branches
+	Version string
+}
+
+// ParseBranch returns the workflow context of a branch name
+func ParseBranch(branchName string, defaultBranchName string) *BranchContext {
+	branchContext := &BranchContext{
+		Name: branchName,
+		Kind: BranchKindOther,
+	}
+	switch {
+	case branchName == defaultBranchName:
+		branchContext.Kind = BranchKindDefault
+	case strings.HasPrefix(branchName, "issue/"):
+		issueBranch, err := ParseIssueBranch(branchName)
+		if err == nil {
+			branchContext.Kind = BranchKindIssue
+			branchContext.Issue = issueBranch
+		}
+	case strings.HasPrefix(branchName, "feature/"):
+		branchContext.Kind = BranchKindFeature
+		branchContext.Feature = strings.TrimPrefix(branchName, "feature/")
+	case strings.HasPrefix(branchName, "release/"):
+		branchContext.Kind = BranchKindRelease
+		branchContext.Version = strings.TrimPrefix(branchName, "release/")
+	}
+	return branchContext
+}
+
+// IsBranchProtected returns true if a branch of a GitHub repository is
+// protected
+func IsBranchProtected(ctx context.Context, client *github.Client, organization string, repository string, branchName string) (bool, error) {
+	branch, _, err := client.Repositories.GetBranch(ctx, organization, repository, branchName)
+	if err != nil {
+		return false, err
+	}
+	return branch.GetProtected(), nil
+}
+
+// BranchReport is the divergence and state of a workflow branch shown by
+// the branch listings
+type BranchReport struct {
+	Name   string `json:"name" yaml:"name"`
+	Local  bool   `json:"local" yaml:"local"`
+	Remote bool   `json:"remote" yaml:"remote"`
+	// Ahead and Behind are counted against the default branch
+	Ahead       int       `json:"ahead" yaml:"ahead"`
+	Behind      int       `json:"behind" yaml:"behind"`
+	Author      string    `json:"author" yaml:"author"`
+	Date        time.Time `json:"date" yaml:"date"`
+	Issue       string    `json:"issue" yaml:"issue"`
+	PullRequest string    `json:"pull_request" yaml:"pull_request"`
+	// Stale is true if the issue of the branch is closed or its pull
+	// request is merged
+	Stale bool `json:"stale" yaml:"stale"`
+	// IssueState and PullRequestState are the bare states of the issue and
+	// pull request, empty if there is none
+	IssueState       string `json:"-" yaml:"-"`
+	PullRequestState string `json:"-" yaml:"-"`
+	// Head is the commit the branch points to and PullRequestHead the head
+	// commit of its pull request
+	Head            string `json:"-" yaml:"-"`
+	PullRequestHead string `json:"-" yaml:"-"`
+	// Committed is true if the branch head is not on the first parent
+	// history of the default branch, so a branch created from it with no
+	// commits of its own is not taken as merged. Branches merged by fast
+	// forward can not be told apart from those and are not committed.
+	Committed bool `json:"-" yaml:"-"`
+}
+
+// Highlighted highlights stale branches on listings
+func (b *BranchReport) Highlighted() bool {
+	return b.Stale
+}
+
+// Reasons to prune a branch
+const (
+	PruneReasonPullRequestMerged = "pull request merged"
+	PruneReasonIssueClosed       = "issue closed"
+	PruneReasonMerged            = "merged"
+)
+
+// PruneReason returns why a branch can be deleted, or an empty string if it
+// can not. Branches are merged when their pull request is merged, which
+// also covers squash merges, or when the default branch moved on from all
+// the commits they had, unless their issue is still open. With an age,
+// branches with no commits for longer are abandoned.
+func (b *BranchReport) PruneReason(olderThan time.Duration, now time.Time) string {
+	switch {
+	case b.PullRequestState == PullRequestStateMerged:
+		return PruneReasonPullRequestMerged
+	case b.IssueState == "closed":
+		return PruneReasonIssueClosed
+	case b.Ahead == 0 && b.Behind > 0 && b.Committed && b.IssueState != "open":
+		return PruneReasonMerged
+	case olderThan > 0 && now.Sub(b.Date) > olderThan:
+		return fmt.Sprintf("last commit %s", HumanizeTime(b.Date, now))
+	}
+	return ""
+}
+
+// CommitsAfterPullRequest returns the number of commits of the branch that
+// are not in the head of its pull request, like follow ups made after it was
+// merged. It fails if the pull request head was never fetched.
+func (b *BranchReport) CommitsAfterPullRequest() (int, error) {
+	if b.PullRequestHead == "" {
+		return 0, fmt.Errorf("Branch %q has no pull request", b.Name)
+	}
+	if b.Head == b.PullRequestHead {
+		return 0, nil
+	}
+	ahead, _, err := automation.GetAheadBehind(b.PullRequestHead, b.Head)
+	if err != nil {
+		return 0, err
+	}
+	return ahead, nil
+}
+
+// ListWorkflowBranches returns the local and origin branches with any of
+// the prefixes, like "feature/", with their divergence from the default
+// branch and their last commit. Everything is computed with go-git.
+func (r *Repository) ListWorkflowBranches(prefixes ...string) ([]*BranchReport, error) {
+	defaultBranchName := r.GitHubRepository.GetDefaultBranch()
+	heads := map[string]plumbing.Hash{}
+	reports := map[string]*BranchReport{}
+	names := []string{}
+	var defaultHash plumbing.Hash
+
+	references, err := r.GitRepository.References()
+	if err != nil {
+		return nil, err
+	}
+	err = references.ForEach(func(reference *plumbing.Reference) error {
+		if reference.Type() != plumbing.HashReference {
+			return nil
+		}
+		referenceName := reference.Name().String()
+		name := ""
+		remote := false
+		switch {
+		case strings.HasPrefix(referenceName, "refs/heads/"):
+			name = strings.TrimPrefix(referenceName, "refs/heads/")
+		case strings.HasPrefix(referenceName, "refs/remotes/origin/"):
+			name = strings.TrimPrefix(referenceName, "refs/remotes/origin/")
+			remote = true
+		default:
+			return nil
+		}
+		if name == defaultBranchName && (remote || defaultHash.IsZero()) {
+			defaultHash = reference.Hash()
+		}
+		if !hasAnyPrefix(name, prefixes) {
+			return nil
+		}
+		report, ok := reports[name]
+		if !ok {
+			report = &BranchReport{Name: name}
+			reports[name] = report
+			names = append(names, name)
+		}
+		// The local branch is the one reported when there are both
+		if remote {
+			report.Remote = true
+			if !report.Local {
+				heads[name] = reference.Hash()
+			}
+		} else {
+			report.Local = true
+			heads[name] = reference.Hash()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if defaultHash.IsZero() {
+		return nil, fmt.Errorf("Default branch %q not found", defaultBranchName)
+	}
+
+	// Commits of the default branch, and of the points branches fork from
+	defaultCommits, err := commitAncestors(r.GitRepository, defaultHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	forkPointCommits := map[plumbing.Hash]map[plumbing.Hash]bool{}
+	firstParents, err := firstParentCommits(r.GitRepository, defaultHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+	result := []*BranchReport{}
+	for _, name := range names {
+		report := reports[name]
+		commit, err := r.GitRepository.CommitObject(heads[name])
+		if err != nil {
+			return nil, err
+		}
+		report.Head = commit.Hash.String()
+		report.Author = commit.Author.Name
+		report.Date = commit.Author.When
+		report.Committed = !firstParents[commit.Hash]
+
+		// The commits ahead are the ones not on the default branch, and
+		// their parents on it are the fork points
+		forkPoints := []plumbing.Hash{}
+		if defaultCommits[commit.Hash] {
+			forkPoints = append(forkPoints, commit.Hash)
+		}
+		iter := object.NewCommitPreorderIter(commit, defaultCommits, nil)
+		err = iter.ForEach(func(ahead *object.Commit) error {
+			report.Ahead++
+			for _, parent := range ahead.ParentHashes {
+				if defaultCommits[parent] {
+					forkPoints = append(forkPoints, parent)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// The commits behind are the ones of the default branch that are
+		// not reachable from any fork point
+		common := map[plumbing.Hash]bool{}
+		for _, forkPoint := range forkPoints {
+			commits, ok := forkPointCommits[forkPoint]
+			if !ok {
+				commits, err = commitAncestors(r.GitRepository, forkPoint, nil)
+				if err != nil {
+					return nil, err
+				}
+				forkPointCommits[forkPoint] = commits
+			}
+			for hash := range commits {
+				common[hash] = true
+			}
+		}
+		report.Behind = len(defaultCommits) - len(common)
+
+		result = append(result, report)
+	}
+	return result, nil
+}
+
+// firstParentCommits returns the hashes of a commit and its first parents,
+// the commits made on a branch without the ones merged into it
+func firstParentCommits(repository *git.Repository, hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	commits := map[plumbing.Hash]bool{}
+	for {
+		commits[hash] = true
+		commit, err := repository.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		if len(commit.ParentHashes) == 0 {
+			return commits, nil
+		}
+		hash = commit.ParentHashes[0]
+	}
+}
+
+// commitAncestors returns the hashes of a commit and all its ancestors,
+// skipping the seen ones
+func commitAncestors(repository *git.Repository, hash plumbing.Hash, seen map[plumbing.Hash]bool) (map[plumbing.Hash]bool, error) {
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	ancestors := map[plumbing.Hash]bool{}
+	err = object.NewCommitPreorderIter(commit, seen, nil).ForEach(func(ancestor *object.Commit) error {
+		ancestors[ancestor.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
+
+// hasAnyPrefix returns true if a string starts with any of the prefixes
+func hasAnyPrefix(str string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(str, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddBranchStates fills the linked issue and pull request states of branch
+// reports from GitHub, and marks as stale the ones whose issue is closed or
+// whose pull request is merged
+func AddBranchStates(ctx context.Context, client *github.Client, organization string, repository string, reports []*BranchReport) error {
+	for _, report := range reports {
+		issueBranch, err := ParseIssueBranch(report.Name)
+		if err == nil && issueBranch.RepositorySlug == "" {
+			issue, err := GetIssue(ctx, client, organization, repository, issueBranch.Number)
+			if err != nil {
+				return err
+			}
+			report.IssueState = issue.GetState()
+			report.Issue = fmt.Sprintf("#%d %s", issue.GetNumber(), issue.GetState())
+			if issue.GetState() == "closed" {
+				report.Stale = true
+			}
+		}
+
+		// The latest pull request of the branch
+		options := &github.PullRequestListOptions{
+			State: "all",
+			Head:  fmt.Sprintf("%s:%s", organization, report.Name),
+		}
+		pullRequests, _, err := client.PullRequests.List(ctx, organization, repository, options)
+		if err != nil {
+			return err
+		}
+		if len(pullRequests) > 0 {
+			pullRequest := pullRequests[0]
+			state := pullRequest.GetState()
+			if pullRequest.MergedAt != nil {
+				state = PullRequestStateMerged
+				report.Stale = true
+			}
+			report.PullRequestState = state
+			report.PullRequestHead = pullRequest.GetHead().GetSHA()
+			report.PullRequest = fmt.Sprintf("#%d %s", pullRequest.GetNumber(), state)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestParseBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *ghub.BranchContext
+	}{
+		{"master", &ghub.BranchContext{Name: "master", Kind: ghub.BranchKindDefault}},
+		{"issue/12-fix-the-thing", &ghub.BranchContext{Name: "issue/12-fix-the-thing", Kind: ghub.BranchKindIssue, Issue: &ghub.IssueBranch{Number: 12, Slug: "fix-the-thing"}}},
+		{"feature/dark-mode", &ghub.BranchContext{Name: "feature/dark-mode", Kind: ghub.BranchKindFeature, Feature: "dark-mode"}},
+		{"release/1.2.3", &ghub.BranchContext{Name: "release/1.2.3", Kind: ghub.BranchKindRelease, Version: "1.2.3"}},
+		{"issue/no-number", &ghub.BranchContext{Name: "issue/no-number", Kind: ghub.BranchKindOther}},
+		{"wip", &ghub.BranchContext{Name: "wip", Kind: ghub.BranchKindOther}},
+	}
+	for _, test := range tests {
+		branchContext := ghub.ParseBranch(test.name, "master")
+		if !reflect.DeepEqual(branchContext, test.expected) {
+			t.Fatalf("Expected branch context %+v but got %+v", test.expected, branchContext)
+		}
+	}
+}
+
+func TestListWorkflowBranches(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	repository, err := git.PlainInit(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	when := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	commit := func(message string) plumbing.Hash {
+		when = when.Add(time.Hour)
+		hash, err := worktree.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "Jane", Email: "jane@example.com", When: when},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	setBranch := func(name string, hash plumbing.Hash) {
+		err := repository.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(name), hash))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// master: A - B - C - M, feature/one forks from A with two commits,
+	// feature/fresh is B with no commits of its own, feature/merged forks
+	// from B with one commit merged by M and issue/2-two, only on origin,
+	// forks from M with one commit
+	a := commit("A")
+	setBranch("refs/heads/feature/one", a)
+	b := commit("B")
+	setBranch("refs/heads/feature/fresh", b)
+	c := commit("C")
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := commit("G")
+	setBranch("refs/heads/feature/merged", g)
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: "refs/heads/master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	when = when.Add(time.Hour)
+	m, err := worktree.Commit("M", &git.CommitOptions{
+		Author:  &object.Signature{Name: "Jane", Email: "jane@example.com", When: when},
+		Parents: []plumbing.Hash{c, g},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setBranch("refs/remotes/origin/master", m)
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: "refs/heads/feature/one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit("D")
+	e := commit("E")
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := commit("F")
+	setBranch("refs/remotes/origin/issue/2-two", f)
+
+	r := &ghub.Repository{
+		GitRepository:    repository,
+		GitHubRepository: &github.Repository{DefaultBranch: github.String("master")},
+	}
+	reports, err := r.ListWorkflowBranches("feature/", "issue/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*ghub.BranchReport{
+		{Name: "feature/fresh", Local: true, Ahead: 0, Behind: 3, Author: "Jane", Date: time.Date(2018, 10, 1, 14, 0, 0, 0, time.UTC), Head: b.String()},
+		{Name: "feature/merged", Local: true, Ahead: 0, Behind: 2, Author: "Jane", Date: time.Date(2018, 10, 1, 16, 0, 0, 0, time.UTC), Head: g.String(), Committed: true},
+		{Name: "feature/one", Local: true, Ahead: 2, Behind: 4, Author: "Jane", Date: time.Date(2018, 10, 1, 19, 0, 0, 0, time.UTC), Head: e.String(), Committed: true},
+		{Name: "issue/2-two", Remote: true, Ahead: 1, Behind: 0, Author: "Jane", Date: time.Date(2018, 10, 1, 20, 0, 0, 0, time.UTC), Head: f.String(), Committed: true},
+	}
+	if len(reports) != len(expected) {
+		t.Fatalf("Expected %d branches but got %d", len(expected), len(reports))
+	}
+	for i, report := range reports {
+		if !report.Date.Equal(expected[i].Date) {
+			t.Fatalf("Expected date %s but got %s", expected[i].Date, report.Date)
+		}
+		report.Date = expected[i].Date
+		if !reflect.DeepEqual(report, expected[i]) {
+			t.Fatalf("Expected branch %+v but got %+v", expected[i], report)
+		}
+	}
+}
+
+func TestListWorkflowBranchesInWorktree(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+	mainPath := filepath.Join(path, "main")
+	worktreePath := filepath.Join(path, "worktree")
+
+	repository, err := git.PlainInit(mainPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := worktree.Commit("A", &git.CommitOptions{
+		Author: &object.Signature{Name: "Jane", Email: "jane@example.com", When: time.Date(2018, 10, 1, 13, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repository.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/master", a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/repejota/git-hub.git"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// go-git can not add linked working trees
+	cmd := exec.Command("git", "worktree", "add", "-b", "feature/two", worktreePath)
+	cmd.Dir = mainPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+
+	r := &ghub.Repository{
+		GitHubRepository: &github.Repository{DefaultBranch: github.String("master")},
+	}
+	err = r.Git(worktreePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.GitRepository.Remote("origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports, err := r.ListWorkflowBranches("feature/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].Name != "feature/two" || !reports[0].Local || reports[0].Head != a.String() {
+		t.Fatalf("Expected the feature/two branch at %s but got %+v", a, reports)
+	}
+}
+
+func TestBranchReportPruneReason(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-100 * 24 * time.Hour)
+	tests := []struct {
+		report   *ghub.BranchReport
+		expected string
+	}{
+		{&ghub.BranchReport{Ahead: 3, Date: recent, PullRequestState: ghub.PullRequestStateMerged}, "pull request merged"},
+		{&ghub.BranchReport{Ahead: 3, Date: recent, IssueState: "closed"}, "issue closed"},
+		{&ghub.BranchReport{Behind: 2, Date: recent, Committed: true}, "merged"},
+		{&ghub.BranchReport{Behind: 2, Date: recent, Committed: true, IssueState: "open"}, ""},
+		{&ghub.BranchReport{Behind: 2, Date: recent}, ""},
+		{&ghub.BranchReport{Date: recent}, ""},
+		{&ghub.BranchReport{Ahead: 1, Date: old}, "last commit 3 months ago"},
+		{&ghub.BranchReport{Ahead: 1, Date: recent}, ""},
+	}
+	for _, test := range tests {
+		reason := test.report.PruneReason(90*24*time.Hour, now)
+		if reason != test.expected {
+			t.Fatalf("Expected reason %q but got %q", test.expected, reason)
+		}
+	}
+}
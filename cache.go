@@ -0,0 +1,67 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CachedLines returns the lines cached under a name if they are younger
+// than ttl, otherwise fetch is called and its result is cached. The cache
+// lives on the user cache directory, so it is shared between invocations.
+func CachedLines(name string, ttl time.Duration, fetch func() ([]string, error)) ([]string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return fetch()
+	}
+	cachePath := filepath.Join(cacheDir, "git-hub", Slugify(name))
+
+	info, err := os.Stat(cachePath)
+	if err == nil && time.Since(info.ModTime()) < ttl {
+		data, err := ioutil.ReadFile(cachePath)
+		if err == nil {
+			return splitLines(string(data)), nil
+		}
+	}
+
+	lines, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	// A cache that can't be written is only slower
+	err = os.MkdirAll(filepath.Dir(cachePath), 0700)
+	if err == nil {
+		ioutil.WriteFile(cachePath, []byte(strings.Join(lines, "\n")), 0600)
+	}
+	return lines, nil
+}
+
+// splitLines splits a text on lines, dropping empty ones
+func splitLines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
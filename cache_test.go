@@ -0,0 +1,66 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestCachedLines(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "git-hub-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	os.Setenv("XDG_CACHE_HOME", cacheDir)
+	defer os.Unsetenv("XDG_CACHE_HOME")
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"12\tFix the thing", "13\tAdd docs"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		lines, err := ghub.CachedLines("issues /path/to/repo", time.Minute, fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"12\tFix the thing", "13\tAdd docs"}
+		if !reflect.DeepEqual(lines, expected) {
+			t.Fatalf("Expected lines %q but got %q", expected, lines)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 fetch but got %d", calls)
+	}
+
+	_, err = ghub.CachedLines("issues /path/to/repo", 0, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("Expected an expired cache to fetch again, got %d fetches", calls)
+	}
+}
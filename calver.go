@@ -0,0 +1,170 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calVerMicro is the token for the micro counter
+const calVerMicro = "MICRO"
+
+// calVerTokens are the date tokens supported on a calendar versioning scheme
+var calVerTokens = map[string]bool{
+	"YYYY": true,
+	"YY":   true,
+	"0Y":   true,
+	"MM":   true,
+	"0M":   true,
+	"WW":   true,
+	"0W":   true,
+	"DD":   true,
+	"0D":   true,
+}
+
+// CalVer ...
+type CalVer struct {
+	Scheme string
+	Parts  []int
+}
+
+// ParseCalVerScheme returns the tokens of a calendar versioning scheme like
+// YYYY.0M.MICRO
+func ParseCalVerScheme(scheme string) ([]string, error) {
+	tokens := strings.Split(scheme, ".")
+	for i, token := range tokens {
+		if token == calVerMicro && i != len(tokens)-1 {
+			return nil, fmt.Errorf("ERROR invalid CalVer scheme, %s must be the last part: %s", calVerMicro, scheme)
+		}
+		if token != calVerMicro && !calVerTokens[token] {
+			return nil, fmt.Errorf("ERROR invalid CalVer scheme: %s", scheme)
+		}
+	}
+	return tokens, nil
+}
+
+// NewCalVer ...
+func NewCalVer(scheme string, version string) (*CalVer, error) {
+	tokens, err := ParseCalVerScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	dataParts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(dataParts) != len(tokens) {
+		return nil, fmt.Errorf("ERROR invalid VERSION format: %s", version)
+	}
+	calver := &CalVer{
+		Scheme: scheme,
+		Parts:  make([]int, len(tokens)),
+	}
+	for i, dataPart := range dataParts {
+		part, err := strconv.Atoi(dataPart)
+		if err != nil {
+			return nil, fmt.Errorf("ERROR invalid %s version part: %s", tokens[i], version)
+		}
+		calver.Parts[i] = part
+	}
+	return calver, nil
+}
+
+// NextCalVer returns the next version for the period of the date now. The
+// micro counter is computed from the existing versions on the same period.
+func NextCalVer(scheme string, now time.Time, versions []string) (*CalVer, error) {
+	tokens, err := ParseCalVerScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	next := &CalVer{
+		Scheme: scheme,
+		Parts:  make([]int, len(tokens)),
+	}
+	for i, token := range tokens {
+		next.Parts[i] = calVerDatePart(token, now)
+	}
+
+	for _, version := range versions {
+		calver, err := NewCalVer(scheme, version)
+		if err != nil {
+			continue
+		}
+		if !next.SamePeriod(calver) {
+			continue
+		}
+		if tokens[len(tokens)-1] != calVerMicro {
+			return nil, fmt.Errorf("ERROR version %s already exists", calver)
+		}
+		micro := calver.Parts[len(tokens)-1] + 1
+		if micro > next.Parts[len(tokens)-1] {
+			next.Parts[len(tokens)-1] = micro
+		}
+	}
+
+	return next, nil
+}
+
+// SamePeriod returns true if both versions belong to the same period, that
+// is, all their date parts are equal.
+func (v *CalVer) SamePeriod(other *CalVer) bool {
+	tokens := strings.Split(v.Scheme, ".")
+	if v.Scheme != other.Scheme || len(v.Parts) != len(other.Parts) {
+		return false
+	}
+	for i, token := range tokens {
+		if token == calVerMicro {
+			continue
+		}
+		if v.Parts[i] != other.Parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *CalVer) String() string {
+	tokens := strings.Split(v.Scheme, ".")
+	strParts := make([]string, len(tokens))
+	for i, token := range tokens {
+		if strings.HasPrefix(token, "0") {
+			strParts[i] = fmt.Sprintf("%02d", v.Parts[i])
+			continue
+		}
+		strParts[i] = strconv.Itoa(v.Parts[i])
+	}
+	return strings.Join(strParts, ".")
+}
+
+// calVerDatePart returns the value of a scheme token for a date
+func calVerDatePart(token string, date time.Time) int {
+	switch token {
+	case "YYYY":
+		return date.Year()
+	case "YY", "0Y":
+		return date.Year() - 2000
+	case "MM", "0M":
+		return int(date.Month())
+	case "WW", "0W":
+		_, week := date.ISOWeek()
+		return week
+	case "DD", "0D":
+		return date.Day()
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestCalVerInstanceStringer(t *testing.T) {
+	expectedVersion := "2018.01.3"
+
+	version, err := ghub.NewCalVer("YYYY.0M.MICRO", expectedVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version.String() != expectedVersion {
+		t.Fatalf("Version expected to be %q but got %q", expectedVersion, version.String())
+	}
+}
+
+func TestInvalidCalVerScheme(t *testing.T) {
+	_, err := ghub.ParseCalVerScheme("YYYY.MICRO.MM")
+	if err == nil {
+		t.Fatal("Expected an error for MICRO not being the last part")
+	}
+	_, err = ghub.ParseCalVerScheme("YYYY.XX")
+	if err == nil {
+		t.Fatal("Expected an error for an unknown token")
+	}
+}
+
+func TestNextCalVer(t *testing.T) {
+	now := time.Date(2018, time.October, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		scheme   string
+		versions []string
+		expected string
+	}{
+		{"YYYY.0M.MICRO", []string{}, "2018.10.0"},
+		{"YYYY.0M.MICRO", []string{"2018.09.4", "2018.10.0", "2018.10.1", "0.0.27"}, "2018.10.2"},
+		{"YY.MM.DD", []string{"18.10.18"}, "18.10.19"},
+		{"YYYY.0W.MICRO", []string{"2018.42.0"}, "2018.42.1"},
+	}
+	for _, test := range tests {
+		version, err := ghub.NextCalVer(test.scheme, now, test.versions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version.String() != test.expected {
+			t.Fatalf("Version expected to be %q but got %q", test.expected, version.String())
+		}
+	}
+}
+
+func TestNextCalVerAlreadyExists(t *testing.T) {
+	now := time.Date(2018, time.October, 19, 0, 0, 0, 0, time.UTC)
+	_, err := ghub.NextCalVer("YY.MM.DD", now, []string{"18.10.19"})
+	if err == nil {
+		t.Fatal("Expected an error for an already existing version")
+	}
+}
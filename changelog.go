@@ -0,0 +1,81 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// yankedMarker is appended to the changelog heading of a yanked release
+const yankedMarker = "[YANKED]"
+
+// YankChangelog records a yanked release on the CHANGELOG.md file at path,
+// marking the release heading or adding one if the release is not there.
+func YankChangelog(path string, version string, reason string) error {
+	changelogFile := filepath.Join(path, "CHANGELOG.md")
+	data, err := ioutil.ReadFile(changelogFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if os.IsNotExist(err) {
+		data = []byte("# Changelog\n")
+	}
+
+	reasonLine := fmt.Sprintf("**Yanked**: %s", reason)
+	headingRegexp := regexp.MustCompile(fmt.Sprintf(`(?m)^##\s+\[?v?%s(\]|[ \t]|$).*$`, regexp.QuoteMeta(version)))
+	lines := strings.Split(string(data), "\n")
+
+	location := headingRegexp.FindIndex(data)
+	if location != nil {
+		// Mark the existing release heading
+		heading := string(data[location[0]:location[1]])
+		if !strings.Contains(heading, yankedMarker) {
+			heading = fmt.Sprintf("%s %s", strings.TrimRight(heading, " "), yankedMarker)
+		}
+		lineNumber := strings.Count(string(data[:location[0]]), "\n")
+		lines[lineNumber] = heading
+		if lineNumber+2 < len(lines) && lines[lineNumber+1] == "" && strings.HasPrefix(lines[lineNumber+2], "**Yanked**:") {
+			// Already yanked, update the reason
+			lines[lineNumber+2] = reasonLine
+		} else {
+			lines = insertLines(lines, lineNumber+1, "", reasonLine)
+		}
+	} else {
+		// Add a release heading after the title
+		lineNumber := 0
+		if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+			lineNumber = 1
+		}
+		heading := fmt.Sprintf("## [%s] %s", version, yankedMarker)
+		lines = insertLines(lines, lineNumber, "", heading, "", reasonLine)
+	}
+
+	return ioutil.WriteFile(changelogFile, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+// insertLines inserts lines at a position
+func insertLines(lines []string, position int, newLines ...string) []string {
+	result := append([]string{}, lines[:position]...)
+	result = append(result, newLines...)
+	return append(result, lines[position:]...)
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestYankChangelog(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	changelog := "# Changelog\n\n## [1.2.4] - 2018-10-19\n\n- Fix\n\n## [1.2.3] - 2018-10-01\n\n- Feature\n"
+	err = ioutil.WriteFile(filepath.Join(path, "CHANGELOG.md"), []byte(changelog), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ghub.YankChangelog(path, "1.2.3", "Broken build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChangelog := "# Changelog\n\n## [1.2.4] - 2018-10-19\n\n- Fix\n\n## [1.2.3] - 2018-10-01 [YANKED]\n\n**Yanked**: Broken build\n\n- Feature\n"
+	data, err := ioutil.ReadFile(filepath.Join(path, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expectedChangelog {
+		t.Fatalf("Expected changelog %q but got %q", expectedChangelog, string(data))
+	}
+}
+
+func TestYankChangelogNewFile(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	err = ghub.YankChangelog(path, "1.2.3", "Broken build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChangelog := "# Changelog\n\n## [1.2.3] [YANKED]\n\n**Yanked**: Broken build\n"
+	data, err := ioutil.ReadFile(filepath.Join(path, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expectedChangelog {
+		t.Fatalf("Expected changelog %q but got %q", expectedChangelog, string(data))
+	}
+}
+
+func TestYankChangelogSimilarVersion(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	changelog := "# Changelog\n\n## [1.2.30] - 2018-10-19\n\n- Fix\n\n## 1.2.3\n\n- Feature\n"
+	err = ioutil.WriteFile(filepath.Join(path, "CHANGELOG.md"), []byte(changelog), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ghub.YankChangelog(path, "1.2.3", "Broken build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChangelog := "# Changelog\n\n## [1.2.30] - 2018-10-19\n\n- Fix\n\n## 1.2.3 [YANKED]\n\n**Yanked**: Broken build\n\n- Feature\n"
+	data, err := ioutil.ReadFile(filepath.Join(path, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expectedChangelog {
+		t.Fatalf("Expected changelog %q but got %q", expectedChangelog, string(data))
+	}
+}
+
+func TestYankChangelogTwice(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	changelog := "# Changelog\n\n## [1.2.3] - 2018-10-01\n\n- Feature\n"
+	err = ioutil.WriteFile(filepath.Join(path, "CHANGELOG.md"), []byte(changelog), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ghub.YankChangelog(path, "1.2.3", "Broken build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ghub.YankChangelog(path, "1.2.3", "Security issue")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChangelog := "# Changelog\n\n## [1.2.3] - 2018-10-01 [YANKED]\n\n**Yanked**: Security issue\n\n- Feature\n"
+	data, err := ioutil.ReadFile(filepath.Join(path, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expectedChangelog {
+		t.Fatalf("Expected changelog %q but got %q", expectedChangelog, string(data))
+	}
+}
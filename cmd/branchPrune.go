@@ -0,0 +1,180 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// RemoteFlag ...
+var RemoteFlag bool
+
+// OlderThanFlag ...
+var OlderThanFlag string
+
+// DryRunFlag ...
+var DryRunFlag bool
+
+// BranchPruneCmd represents the branch prune command
+var BranchPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete merged and abandoned branches",
+	Long:  `Delete the feature and issue branches merged into the default branch, including squash merged pull requests, the ones whose issue is closed and optionally the ones with no recent commits`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// --older-than
+		var olderThan time.Duration
+		if OlderThanFlag != "" {
+			age, err := ghub.ParseAge(OlderThanFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			olderThan = age
+		}
+
+		currentBranchName, err := automation.GetCurrentBranch()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Branches to prune, remote ones only with --remote
+		now := time.Now()
+		type prune struct {
+			report *ghub.BranchReport
+			local  bool
+			remote bool
+		}
+		prunes := []prune{}
+		for _, report := range listBranches(gitHubToken, "feature/", "issue/") {
+			if report.Name == currentBranchName {
+				continue
+			}
+			reason := report.PruneReason(olderThan, now)
+			if reason == "" {
+				continue
+			}
+
+			// --force
+			// Commits made after the pull request, or not merged at all,
+			// would be lost
+			if reason == ghub.PruneReasonPullRequestMerged && !ForceFlag {
+				after, err := report.CommitsAfterPullRequest()
+				if err != nil || after > 0 {
+					fmt.Println(color.YellowString("%s (%s, has commits after it, use --force to delete it)", report.Name, reason))
+					continue
+				}
+			} else if reason != ghub.PruneReasonMerged && report.Ahead > 0 && !ForceFlag {
+				fmt.Println(color.YellowString("%s (%s, has %d unmerged commits, use --force to delete it)", report.Name, reason, report.Ahead))
+				continue
+			}
+			p := prune{report: report, local: report.Local, remote: RemoteFlag && report.Remote}
+			if !p.local && !p.remote {
+				continue
+			}
+			prunes = append(prunes, p)
+
+			where := "local"
+			switch {
+			case p.local && p.remote:
+				where = "local and remote"
+			case p.remote:
+				where = "remote"
+			}
+			fmt.Printf("%s (%s, %s)\n", report.Name, reason, where)
+		}
+		if len(prunes) == 0 {
+			fmt.Println("No branches to prune")
+			return
+		}
+
+		// --dry-run
+		if DryRunFlag {
+			return
+		}
+
+		// --yes
+		if !YesFlag {
+			confirmed, err := ghub.Confirm(fmt.Sprintf("Delete %d branches?", len(prunes)))
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			if !confirmed {
+				os.Exit(1)
+			}
+		}
+
+		// Squash merged branches are not merged for git, so local
+		// branches are force deleted
+		for _, p := range prunes {
+			if p.remote {
+				_, err := automation.DeleteRemoteBranch(p.report.Name)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			if p.local {
+				_, err := automation.ForceDeleteLocalBranch(p.report.Name)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			fmt.Println(color.GreenString("Deleted %s", p.report.Name))
+		}
+	},
+}
+
+func init() {
+	BranchPruneCmd.Flags().BoolVarP(&RemoteFlag, "remote", "", false, "Delete the branches on origin too")
+	BranchPruneCmd.Flags().StringVarP(&OlderThanFlag, "older-than", "", "", "Delete branches with no commits for longer, like 90d")
+	BranchPruneCmd.Flags().BoolVarP(&DryRunFlag, "dry-run", "n", false, "List the branches to delete without deleting them")
+	BranchPruneCmd.Flags().BoolVarP(&ForceFlag, "force", "f", false, "Delete branches with unmerged commits or commits after their merged pull request")
+	BranchPruneCmd.Flags().BoolVarP(&YesFlag, "yes", "y", false, "Do not ask for confirmation")
+}
@@ -0,0 +1,259 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/github"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completionAnnotation is the command and flag annotation naming the kind
+// of values their arguments are completed with
+const completionAnnotation = "git-hub_completion"
+
+// Completion kinds
+const (
+	completeIssues   = "issues"
+	completeFeatures = "features"
+	completeVersions = "versions"
+)
+
+// completionCacheTTL is how long completions fetched from GitHub are reused
+const completionCacheTTL = time.Minute
+
+// completionScripts are the shell completion scripts, all of them ask the
+// hidden __complete command for the candidates
+var completionScripts = map[string]string{
+	"bash": `# bash completion for git-hub, also used by "git hub"
+_git_hub() {
+    local IFS=$'\n' start=1
+    [[ ${COMP_WORDS[0]} == git ]] && start=2
+    COMPREPLY=( $(git-hub __complete "${COMP_WORDS[@]:start:COMP_CWORD-start+1}" 2>/dev/null | cut -f1) )
+}
+complete -o default -F _git_hub git-hub
+`,
+	"zsh": `#compdef git-hub
+# zsh completion for git-hub
+_git-hub() {
+    local -a completions
+    completions=("${(@f)$(git-hub __complete "${(@)words[2,CURRENT]}" 2>/dev/null | sed -e 's/:/\\:/g' -e 's/	/:/')}")
+    _describe 'git-hub' completions
+}
+compdef _git-hub git-hub
+`,
+	"fish": `# fish completion for git-hub
+complete -c git-hub -f -a '(git-hub __complete (commandline -opc)[2..-1] (commandline -ct))'
+`,
+}
+
+// CompletionCmd represents the completion command
+var CompletionCmd = &cobra.Command{
+	Use:       "completion bash|zsh|fish",
+	Short:     "Generate shell completion",
+	Long:      `Generate the completion script for bash, zsh or fish, completing commands, flags, issue numbers, branches and versions`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Run: func(cmd *cobra.Command, args []string) {
+		script, ok := completionScripts[args[0]]
+		if !ok {
+			fmt.Println(color.RedString("ERROR: %s %q", "Unsupported shell", args[0]))
+			os.Exit(1)
+		}
+		fmt.Print(script)
+	},
+}
+
+// CompleteCmd represents the hidden command the completion scripts call
+// with the words typed so far, the last one being completed
+var CompleteCmd = &cobra.Command{
+	Use:                "__complete",
+	Hidden:             true,
+	DisableFlagParsing: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --github-token
+		// Get the GitHub Token from env, flags are not parsed
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+
+		for _, candidate := range completeArgs(cmd.Root(), args, gitHubToken) {
+			fmt.Println(candidate)
+		}
+	},
+}
+
+// completeArgs returns the candidates, with an optional tab separated
+// description, for the last of the words typed after the program name
+func completeArgs(root *cobra.Command, args []string, gitHubToken string) []string {
+	toComplete := ""
+	if len(args) > 0 {
+		toComplete = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	cmd, _, err := root.Find(args)
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{}
+	switch {
+	case len(args) > 0 && completingFlagValue(cmd, args[len(args)-1]) != nil:
+		flag := completingFlagValue(cmd, args[len(args)-1])
+		if kinds, ok := flag.Annotations[completionAnnotation]; ok {
+			candidates = completionCandidates(kinds[0], gitHubToken)
+		}
+	case strings.HasPrefix(toComplete, "-"):
+		addFlag := func(flag *pflag.Flag) {
+			if !flag.Hidden {
+				candidates = append(candidates, fmt.Sprintf("--%s\t%s", flag.Name, flag.Usage))
+			}
+		}
+		cmd.LocalFlags().VisitAll(addFlag)
+		cmd.InheritedFlags().VisitAll(addFlag)
+	case cmd.HasAvailableSubCommands():
+		for _, subCmd := range cmd.Commands() {
+			if subCmd.IsAvailableCommand() {
+				candidates = append(candidates, fmt.Sprintf("%s\t%s", subCmd.Name(), subCmd.Short))
+			}
+		}
+	case len(cmd.ValidArgs) > 0:
+		candidates = cmd.ValidArgs
+	default:
+		if kind, ok := cmd.Annotations[completionAnnotation]; ok {
+			candidates = completionCandidates(kind, gitHubToken)
+		}
+	}
+
+	matches := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// completingFlagValue returns the flag of a word if it is a flag waiting
+// for a value
+func completingFlagValue(cmd *cobra.Command, word string) *pflag.Flag {
+	if !strings.HasPrefix(word, "-") || strings.Contains(word, "=") {
+		return nil
+	}
+	var flag *pflag.Flag
+	if strings.HasPrefix(word, "--") {
+		flag = cmd.Flags().Lookup(strings.TrimPrefix(word, "--"))
+	} else {
+		flag = cmd.Flags().ShorthandLookup(strings.TrimPrefix(word, "-"))
+	}
+	if flag == nil || flag.NoOptDefVal != "" {
+		return nil
+	}
+	return flag
+}
+
+// completionCandidates returns the values of a completion kind, failures
+// are logged and complete nothing
+func completionCandidates(kind string, gitHubToken string) []string {
+	path := "."
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	switch kind {
+	case completeIssues:
+		lines, err := ghub.CachedLines("issues "+cwd, completionCacheTTL, func() ([]string, error) {
+			repository, err := ghub.OpenRepository(path, gitHubToken)
+			if err != nil {
+				return nil, err
+			}
+			ctx := context.Background()
+			client := ghub.NewGitHubClient(ctx, gitHubToken)
+			options := &ghub.IssueListOptions{
+				IssueListByRepoOptions: github.IssueListByRepoOptions{
+					State: "open",
+				},
+				Limit:        100,
+				PullRequests: ghub.PullRequestsExclude,
+			}
+			issues, err := ghub.ListIssuesByRepo(ctx, client, repository.GitHubRepository.GetFullName(), options)
+			if err != nil {
+				return nil, err
+			}
+			lines := []string{}
+			for _, issue := range issues {
+				lines = append(lines, fmt.Sprintf("%d\t%s", issue.GetNumber(), issue.GetTitle()))
+			}
+			return lines, nil
+		})
+		if err != nil {
+			log.Println(err)
+		}
+		return lines
+	case completeFeatures:
+		branchNames, err := automation.ListLocalBranches()
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		lines := []string{}
+		for _, branchName := range branchNames {
+			if strings.HasPrefix(branchName, "feature/") {
+				lines = append(lines, branchName)
+			}
+		}
+		return lines
+	case completeVersions:
+		lines, err := ghub.CachedLines("versions "+cwd, completionCacheTTL, func() ([]string, error) {
+			repository, err := ghub.OpenRepository(path, gitHubToken)
+			if err != nil {
+				return nil, err
+			}
+			versions, err := repository.CandidateVersions()
+			if err != nil {
+				return nil, err
+			}
+			lines := []string{}
+			for _, version := range versions {
+				lines = append(lines, version.String())
+			}
+			return lines, nil
+		})
+		if err != nil {
+			log.Println(err)
+		}
+		return lines
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// NoFFFlag ...
+var NoFFFlag bool
+
+// FeatureFinishCmd represents the feature finish command
+var FeatureFinishCmd = &cobra.Command{
+	Use:         "finish [feature branch]",
+	Short:       "Finish a feature",
+	Long:        `Get the current feature branch, or the given one, into the default branch and delete it, or open a pull request when the default branch is protected`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{completionAnnotation: completeFeatures},
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// --no-ff, --squash or --rebase
+		strategy := ""
+		strategies := 0
+		if NoFFFlag {
+			strategy = ghub.FeatureMergeNoFF
+			strategies++
+		}
+		if SquashFlag {
+			strategy = ghub.FeatureMergeSquash
+			strategies++
+		}
+		if RebaseFlag {
+			strategy = ghub.FeatureMergeRebase
+			strategies++
+		}
+		if strategies > 1 {
+			fmt.Println(color.RedString("ERROR: %s", "Only one of --no-ff, --squash or --rebase can be given"))
+			os.Exit(1)
+		}
+
+		// Go to the given feature branch
+		if len(args) > 0 {
+			out, err := automation.GoGitBranch(args[0])
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Println(out)
+		}
+
+		repositoryPath := "."
+
+		options := &ghub.FeatureOptions{
+			Strategy:    strategy,
+			PullRequest: PullRequestFlag,
+		}
+		ghub.FeatureFinish(repositoryPath, gitHubToken, options)
+	},
+}
+
+func init() {
+	FeatureFinishCmd.Flags().BoolVarP(&NoFFFlag, "no-ff", "", false, "Merge with a merge commit, the default")
+	FeatureFinishCmd.Flags().BoolVarP(&SquashFlag, "squash", "s", false, "Squash the feature commits into one")
+	FeatureFinishCmd.Flags().BoolVarP(&RebaseFlag, "rebase", "", false, "Rebase the feature commits onto the default branch")
+	FeatureFinishCmd.Flags().BoolVarP(&PullRequestFlag, "pull-request", "", false, "Open a pull request from the feature branch instead of merging it")
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// FeatureListCmd represents the feature list command
+var FeatureListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List feature and issue branches",
+	Long:  `List local and remote feature and issue branches with their commits ahead and behind of the default branch, last commit and linked issue and pull request, highlighting the ones whose issue is closed or whose pull request is merged`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		render(listBranches(gitHubToken, "feature/", "issue/"))
+	},
+}
+
+// listBranches returns the reports of the branches with any of the prefixes
+func listBranches(gitHubToken string, prefixes ...string) []*ghub.BranchReport {
+	// Open repository
+	path := "."
+	repository, err := ghub.OpenRepository(path, gitHubToken)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+
+	reports, err := repository.ListWorkflowBranches(prefixes...)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client := ghub.NewGitHubClient(ctx, gitHubToken)
+	org := repository.GitHubRepository.GetOwner().GetLogin()
+	repo := repository.GitHubRepository.GetName()
+	err = ghub.AddBranchStates(ctx, client, org, repo, reports)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	return reports
+}
@@ -32,20 +32,61 @@ var (
 func main() {
 	cmd.RootCmd.SetVersionTemplate(`{{with .Name}}{{printf "%s " .}}{{end}}{{printf "%s" .Version}}`)
 	cmd.RootCmd.Version = ghub.ShowVersionInfo(Version, Build)
+	cmd.AppVersion.Version = Version
+	cmd.AppVersion.Build = Build
 
 	cmd.RootCmd.AddCommand(cmd.InfoCmd)
+	cmd.RootCmd.AddCommand(cmd.StatusCmd)
+	cmd.RootCmd.AddCommand(cmd.SyncCmd)
+	cmd.RootCmd.AddCommand(cmd.CompletionCmd)
+	cmd.RootCmd.AddCommand(cmd.CompleteCmd)
 
 	cmd.IssueCmd.AddCommand(cmd.IssueListCmd)
 	cmd.IssueCmd.AddCommand(cmd.IssueStartCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueFinishCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueBranchesCmd)
 	cmd.IssueCmd.AddCommand(cmd.IssueNewCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueCreateCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueViewCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueCommentCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueEditCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueCloseCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueReopenCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueLabelCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueUnlabelCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueAssignCmd)
+	cmd.IssueCmd.AddCommand(cmd.IssueUnassignCmd)
 	cmd.RootCmd.AddCommand(cmd.IssueCmd)
 
+	cmd.PullRequestCmd.AddCommand(cmd.PullRequestCreateCmd)
+	cmd.PullRequestCmd.AddCommand(cmd.PullRequestListCmd)
+	cmd.PullRequestCmd.AddCommand(cmd.PullRequestViewCmd)
+	cmd.PullRequestCmd.AddCommand(cmd.PullRequestCheckoutCmd)
+	cmd.PullRequestCmd.AddCommand(cmd.PullRequestMergeCmd)
+	cmd.RootCmd.AddCommand(cmd.PullRequestCmd)
+
 	cmd.FeatureCmd.AddCommand(cmd.FeatureStartCmd)
+	cmd.FeatureCmd.AddCommand(cmd.FeatureFinishCmd)
+	cmd.FeatureCmd.AddCommand(cmd.FeatureListCmd)
 	cmd.RootCmd.AddCommand(cmd.FeatureCmd)
 
+	cmd.BranchCmd.AddCommand(cmd.BranchPruneCmd)
+	cmd.RootCmd.AddCommand(cmd.BranchCmd)
+
+	cmd.StackCmd.AddCommand(cmd.StackStartCmd)
+	cmd.StackCmd.AddCommand(cmd.StackSyncCmd)
+	cmd.StackCmd.AddCommand(cmd.StackSubmitCmd)
+	cmd.RootCmd.AddCommand(cmd.StackCmd)
+
+	cmd.WorktreeCmd.AddCommand(cmd.WorktreeListCmd)
+	cmd.WorktreeCmd.AddCommand(cmd.WorktreeCleanCmd)
+	cmd.RootCmd.AddCommand(cmd.WorktreeCmd)
+
 	cmd.ReleaseCmd.AddCommand(cmd.ReleaseStartCmd)
 	cmd.ReleaseCmd.AddCommand(cmd.ReleaseFinishCmd)
 	cmd.ReleaseCmd.AddCommand(cmd.ReleasePatchCmd)
+	cmd.ReleaseCmd.AddCommand(cmd.ReleaseMajorCmd)
+	cmd.ReleaseCmd.AddCommand(cmd.ReleaseYankCmd)
 	cmd.RootCmd.AddCommand(cmd.ReleaseCmd)
 
 	cmd.RootCmd.AddCommand(cmd.VersionCmd)
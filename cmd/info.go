@@ -63,9 +63,6 @@ var InfoCmd = &cobra.Command{
 		}
 
 		// Print info
-		fmt.Printf("Git Repository Path: %s\n", path)
-		fmt.Printf("GitHub Repository ID: %d\n", repository.GitHubRepository.ID)
-		fmt.Printf("GitHub Repository Full Name: %s\n", *repository.GitHubRepository.FullName)
-		fmt.Printf("GitHub Repository HTML URL: %s\n", *repository.GitHubRepository.HTMLURL)
+		render(repository.Info())
 	},
 }
@@ -18,17 +18,28 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/google/go-github/github"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/repejota/git-hub/output"
 	"github.com/spf13/cobra"
 )
 
 // Repository ...
 var Repository string
 
+// QueryFlag ...
+var QueryFlag string
+
 // IssueCmd represents the issue command
 var IssueCmd = &cobra.Command{
 	Use:   "issue",
@@ -58,3 +69,119 @@ var IssueCmd = &cobra.Command{
 		os.Exit(0)
 	},
 }
+
+// issueTargets returns the organization and name of the repository, from
+// the current repository or the --repository flag, and the issue numbers an
+// issue command works on, from its arguments or the --query flag.
+func issueTargets(ctx context.Context, client *github.Client, gitHubToken string, args []string) (string, string, []int) {
+	org, repo := issueRepository(gitHubToken)
+
+	// Issue numbers should be integers
+	numbers := []int{}
+	for _, arg := range args {
+		number, err := strconv.Atoi(arg)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid issue ID", arg))
+			os.Exit(1)
+		}
+		numbers = append(numbers, number)
+	}
+
+	// --query flag
+	if QueryFlag != "" {
+		found, err := ghub.SearchIssueNumbers(ctx, client, org, repo, QueryFlag)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		numbers = append(numbers, found...)
+	}
+
+	if len(numbers) == 0 {
+		fmt.Println(color.RedString("ERROR: %s", "An issue ID or a --query is required"))
+		os.Exit(1)
+	}
+
+	return org, repo, numbers
+}
+
+// issueRepository returns the organization and name of the repository of
+// the issues, from the current repository or the --repository flag
+func issueRepository(gitHubToken string) (string, string) {
+	// Open repository
+	path := "."
+	r, err := ghub.OpenRepository(path, gitHubToken)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+
+	// --repository flag
+	repository := *r.GitHubRepository.FullName
+	if Repository != "" {
+		repository = Repository
+	}
+	return ghub.ParseRepositoryFullName(repository)
+}
+
+// pickIssue lets the user pick one of the open issues assigned to them or
+// unassigned, with a fuzzy finder on a terminal or a numbered prompt
+// otherwise, and returns its number
+func pickIssue(ctx context.Context, client *github.Client, org string, repo string) int {
+	user, err := ghub.GetAuthenticatedUser(ctx, client)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	issues, err := ghub.ListPickableIssues(ctx, client, fmt.Sprintf("%s/%s", org, repo), user.GetLogin())
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	if len(issues) == 0 {
+		fmt.Println(color.RedString("ERROR: %s", "There are no open issues to pick"))
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	lines := []string{}
+	for _, issue := range issues {
+		lines = append(lines, ghub.FormatIssueLine(issue, now))
+	}
+
+	var choice int
+	if output.IsInteractive() {
+		choice, err = output.Find("Issue>", lines, func(i int) string {
+			return fmt.Sprintf("#%d %s\n\n%s", issues[i].GetNumber(), issues[i].GetTitle(), issues[i].GetBody())
+		})
+	} else {
+		choice, err = ghub.Choose("Issue", lines)
+	}
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	return issues[choice].GetNumber()
+}
+
+// readBody returns a text given on a flag, from stdin if it is "-" or
+// from the user editor if it is empty
+func readBody(body string, initial string) string {
+	if body == "-" {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		return string(data)
+	}
+	if body != "" {
+		return body
+	}
+	text, err := automation.EditText(initial, "ISSUE_EDITMSG*.md")
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	return text
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// IssueAssignCmd represents the issue assign command
+var IssueAssignCmd = &cobra.Command{
+	Use:   "assign [issue number...]",
+	Short: "Assign issues",
+	Long:  `Assign one or more issues, to you unless --assignee is given`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// Without assignees use the authenticated user
+		assignees := AssigneesFlag
+		if len(assignees) == 0 {
+			user, err := ghub.GetAuthenticatedUser(ctx, client)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			assignees = []string{user.GetLogin()}
+		}
+
+		for _, number := range numbers {
+			err := ghub.AddAssigneesToIssue(ctx, client, org, repo, number, assignees)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Assigned @%s on issue #%d\n", strings.Join(assignees, ", @"), number)
+		}
+	},
+}
+
+// IssueUnassignCmd represents the issue unassign command
+var IssueUnassignCmd = &cobra.Command{
+	Use:   "unassign [issue number...]",
+	Short: "Unassign issues",
+	Long:  `Unassign one or more issues, from you unless --assignee is given`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// Without assignees use the authenticated user
+		assignees := AssigneesFlag
+		if len(assignees) == 0 {
+			user, err := ghub.GetAuthenticatedUser(ctx, client)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			assignees = []string{user.GetLogin()}
+		}
+
+		for _, number := range numbers {
+			err := ghub.RemoveAssigneesFromIssue(ctx, client, org, repo, number, assignees)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Unassigned @%s on issue #%d\n", strings.Join(assignees, ", @"), number)
+		}
+	},
+}
+
+func init() {
+	IssueAssignCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueAssignCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueAssignCmd.Flags().StringSliceVarP(&AssigneesFlag, "assignee", "a", nil, "User to assign, can be repeated")
+	IssueUnassignCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueUnassignCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueUnassignCmd.Flags().StringSliceVarP(&AssigneesFlag, "assignee", "a", nil, "User to unassign, can be repeated")
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// CommentFlag ...
+var CommentFlag string
+
+// IssueCloseCmd represents the issue close command
+var IssueCloseCmd = &cobra.Command{
+	Use:         "close [issue number...]",
+	Short:       "Close issues",
+	Long:        `Close one or more issues, optionally with a reason and a comment, with no issue number an open issue is picked interactively`,
+	Args:        cobra.ArbitraryArgs,
+	Annotations: map[string]string{completionAnnotation: completeIssues},
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+
+		// Pick the issue when none is given
+		if len(args) == 0 && QueryFlag == "" {
+			org, repo := issueRepository(gitHubToken)
+			args = []string{strconv.Itoa(pickIssue(ctx, client, org, repo))}
+		}
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// --reason
+		switch ReasonFlag {
+		case "", ghub.CloseReasonCompleted, ghub.CloseReasonNotPlanned:
+		default:
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid close reason", ReasonFlag))
+			os.Exit(1)
+		}
+
+		for _, number := range numbers {
+			// --comment
+			if CommentFlag != "" {
+				_, err := ghub.CommentIssue(ctx, client, org, repo, number, CommentFlag)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			err := ghub.CloseIssue(ctx, client, org, repo, number, ReasonFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Closed issue #%d\n", number)
+		}
+	},
+}
+
+// IssueReopenCmd represents the issue reopen command
+var IssueReopenCmd = &cobra.Command{
+	Use:   "reopen [issue number...]",
+	Short: "Reopen issues",
+	Long:  `Reopen one or more closed issues`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		for _, number := range numbers {
+			err := ghub.ReopenIssue(ctx, client, org, repo, number)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Reopened issue #%d\n", number)
+		}
+	},
+}
+
+func init() {
+	IssueCloseCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueCloseCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueCloseCmd.Flags().StringVarP(&ReasonFlag, "reason", "", "", "Close reason: completed or not_planned")
+	IssueCloseCmd.Flags().StringVarP(&CommentFlag, "comment", "c", "", "Leave a comment before closing")
+	IssueReopenCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueReopenCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+}
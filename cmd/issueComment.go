@@ -0,0 +1,85 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// IssueCommentCmd represents the issue comment command
+var IssueCommentCmd = &cobra.Command{
+	Use:   "comment [issue number...]",
+	Short: "Comment on issues",
+	Long:  `Comment on one or more issues, the body is read from --body, from stdin with --body - or from the editor`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// Comment body from the flag, stdin or the editor
+		commentBody := strings.TrimSpace(readBody(BodyFlag, ""))
+		if commentBody == "" {
+			fmt.Println(color.RedString("ERROR: %s", "Aborting comment due to empty body"))
+			os.Exit(1)
+		}
+
+		for _, number := range numbers {
+			comment, err := ghub.CommentIssue(ctx, client, org, repo, number, commentBody)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Commented on issue #%d %s\n", number, comment.GetHTMLURL())
+		}
+	},
+}
+
+func init() {
+	IssueCommentCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueCommentCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueCommentCmd.Flags().StringVarP(&BodyFlag, "body", "b", "", "Comment body, - reads it from stdin")
+}
@@ -0,0 +1,193 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// TitleFlag ...
+var TitleFlag string
+
+// BodyFlag ...
+var BodyFlag string
+
+// AssigneesFlag ...
+var AssigneesFlag []string
+
+// WebFlag ...
+var WebFlag bool
+
+// IssueCreateCmd represents the issue create command
+var IssueCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an issue",
+	Long:  `Create an issue from the terminal, with no title an editor is opened on the chosen issue template`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// Open repository
+		path := "."
+		repo, err := ghub.OpenRepository(path, gitHubToken)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// --repository flag
+		repository := *repo.GitHubRepository.FullName
+		if Repository != "" {
+			repository = Repository
+		}
+
+		// --web
+		// Create the issue on the browser
+		if WebFlag {
+			query := url.Values{}
+			if TitleFlag != "" {
+				query.Set("title", TitleFlag)
+			}
+			if BodyFlag != "" {
+				query.Set("body", BodyFlag)
+			}
+			if len(LabelsFlag) > 0 {
+				query.Set("labels", strings.Join(LabelsFlag, ","))
+			}
+			issueURL := repo.GetNewIssueURL(repository)
+			if len(query) > 0 {
+				issueURL = fmt.Sprintf("%s?%s", issueURL, query.Encode())
+			}
+			automation.OpenURL(issueURL)
+			return
+		}
+
+		title := TitleFlag
+		body := BodyFlag
+		labels := LabelsFlag
+		assignees := AssigneesFlag
+
+		// Without a title edit the issue from a template
+		if title == "" {
+			templates, err := ghub.LoadIssueTemplates(path)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			template := &ghub.IssueTemplate{}
+			if len(templates) > 0 {
+				options := []string{}
+				for _, template := range templates {
+					options = append(options, fmt.Sprintf("%s - %s", template.Name, template.About))
+				}
+				options = append(options, "Blank issue")
+				choice, err := ghub.Choose("Choose an issue template", options)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				if choice < len(templates) {
+					template = templates[choice]
+				}
+			}
+			if len(labels) == 0 {
+				labels = template.Labels
+			}
+			if len(assignees) == 0 {
+				assignees = template.Assignees
+			}
+
+			// The first line is the title and the rest the body
+			text := fmt.Sprintf("%s\n\n%s", template.Title, body)
+			if body == "" {
+				text = fmt.Sprintf("%s\n\n%s", template.Title, template.Body)
+			}
+			text, err = automation.EditText(text, "ISSUE_EDITMSG*.md")
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			title, body = ghub.ParseIssueText(text)
+			if title == "" {
+				fmt.Println(color.RedString("ERROR: %s", "Aborting issue creation due to empty title"))
+				os.Exit(1)
+			}
+		}
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repoName := ghub.ParseRepositoryFullName(repository)
+		issueRequest := ghub.NewIssueRequest(title, body, labels, assignees)
+
+		// --milestone
+		if MilestoneFlag != "" {
+			milestoneNumber, err := ghub.ResolveMilestoneNumber(ctx, client, org, repoName, MilestoneFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			issueRequest.Milestone = &milestoneNumber
+		}
+
+		// Create the issue
+		issue, err := ghub.CreateIssue(ctx, client, org, repoName, issueRequest)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Printf("Created issue #%d %s\n", issue.GetNumber(), issue.GetHTMLURL())
+	},
+}
+
+func init() {
+	IssueCreateCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to create the issue")
+	IssueCreateCmd.Flags().StringVarP(&TitleFlag, "title", "t", "", "Issue title, without it an editor is opened")
+	IssueCreateCmd.Flags().StringVarP(&BodyFlag, "body", "b", "", "Issue body")
+	IssueCreateCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Add a label, can be repeated")
+	IssueCreateCmd.Flags().StringSliceVarP(&AssigneesFlag, "assignee", "a", nil, "Assign a user, can be repeated")
+	IssueCreateCmd.Flags().StringVarP(&MilestoneFlag, "milestone", "m", "", "Milestone title or number")
+	IssueCreateCmd.Flags().BoolVarP(&WebFlag, "web", "w", false, "Create the issue on the browser")
+}
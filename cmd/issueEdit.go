@@ -0,0 +1,121 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/github"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// IssueEditCmd represents the issue edit command
+var IssueEditCmd = &cobra.Command{
+	Use:   "edit [issue number...]",
+	Short: "Edit issues",
+	Long:  `Edit the title, body or milestone of issues, without flags the editor is opened`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		issueRequest := &github.IssueRequest{}
+		if TitleFlag != "" {
+			issueRequest.Title = &TitleFlag
+		}
+		if BodyFlag != "" {
+			body := readBody(BodyFlag, "")
+			issueRequest.Body = &body
+		}
+
+		// --milestone
+		if MilestoneFlag != "" {
+			milestoneNumber, err := ghub.ResolveMilestoneNumber(ctx, client, org, repo, MilestoneFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			issueRequest.Milestone = &milestoneNumber
+		}
+
+		// Without changes edit the title and body of a single issue on the
+		// editor
+		if TitleFlag == "" && BodyFlag == "" && MilestoneFlag == "" {
+			if len(numbers) != 1 {
+				fmt.Println(color.RedString("ERROR: %s", "Only one issue can be edited on the editor"))
+				os.Exit(1)
+			}
+			issue, err := ghub.GetIssue(ctx, client, org, repo, numbers[0])
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			text := readBody("", fmt.Sprintf("%s\n\n%s\n", issue.GetTitle(), issue.GetBody()))
+			title, body := ghub.ParseIssueText(text)
+			if title == "" {
+				fmt.Println(color.RedString("ERROR: %s", "Aborting issue edition due to empty title"))
+				os.Exit(1)
+			}
+			issueRequest.Title = &title
+			issueRequest.Body = &body
+		}
+
+		for _, number := range numbers {
+			issue, err := ghub.EditIssue(ctx, client, org, repo, number, issueRequest)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Edited issue #%d %s\n", number, issue.GetHTMLURL())
+		}
+	},
+}
+
+func init() {
+	IssueEditCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueEditCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueEditCmd.Flags().StringVarP(&TitleFlag, "title", "t", "", "New title")
+	IssueEditCmd.Flags().StringVarP(&BodyFlag, "body", "b", "", "New body, - reads it from stdin")
+	IssueEditCmd.Flags().StringVarP(&MilestoneFlag, "milestone", "m", "", "Milestone title or number")
+}
@@ -0,0 +1,188 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/github"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// IssueFinishCmd represents the issue finish command
+var IssueFinishCmd = &cobra.Command{
+	Use:   "finish",
+	Short: "Finish an issue",
+	Long:  `Push the current issue branch and open a pull request that closes the issue`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// Open repository
+		path := "."
+		r, err := ghub.OpenRepository(path, gitHubToken)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Get the issue number from the current branch
+		currentBranchName, err := automation.GetCurrentBranch()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		issueBranch, err := ghub.ParseIssueBranch(currentBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// --repository flag
+		// Issue branches of another repository need it to find the issue
+		repository := r.GitHubRepository.GetFullName()
+		closes := fmt.Sprintf("Closes #%d", issueBranch.Number)
+		if issueBranch.RepositorySlug != "" {
+			if Repository == "" || ghub.SlugifyRepository(Repository) != issueBranch.RepositorySlug {
+				fmt.Println(color.RedString("ERROR: Branch %q belongs to an issue of another repository, use --repository", currentBranchName))
+				os.Exit(1)
+			}
+			repository = Repository
+			closes = fmt.Sprintf("Closes %s#%d", repository, issueBranch.Number)
+		}
+
+		// Get Issue
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		issueOrg, issueRepo := ghub.ParseRepositoryFullName(repository)
+		issue, err := ghub.GetIssue(ctx, client, issueOrg, issueRepo, issueBranch.Number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Push outstanding commits
+		out, err := automation.PushLocalBranchToOrigin(currentBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(out)
+
+		// Label set on the issue while it is in progress, if any
+		inProgressLabel, err := automation.GetConfig("inProgressLabel")
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Open the pull request, unless there is one already
+		org, repo := ghub.ParseRepositoryFullName(r.GitHubRepository.GetFullName())
+		pullRequest, err := ghub.FindOpenPullRequest(ctx, client, org, repo, currentBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		if pullRequest != nil {
+			fmt.Printf("Pull request #%d already open %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+		} else {
+			title := issue.GetTitle()
+			baseBranchName := r.GitHubRepository.GetDefaultBranch()
+			pullRequest, err = ghub.CreatePullRequest(ctx, client, org, repo, &github.NewPullRequest{
+				Title: &title,
+				Head:  &currentBranchName,
+				Base:  &baseBranchName,
+				Body:  &closes,
+			})
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Opened pull request #%d %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+
+			// Copy labels and milestone from the issue, milestones are only
+			// meaningful on the same repository
+			labels := []string{}
+			for _, label := range issue.Labels {
+				if label.GetName() != inProgressLabel {
+					labels = append(labels, label.GetName())
+				}
+			}
+			if len(labels) > 0 {
+				err = ghub.AddLabelsToIssue(ctx, client, org, repo, pullRequest.GetNumber(), labels)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			if issue.Milestone != nil && issueBranch.RepositorySlug == "" {
+				milestone := issue.Milestone.GetNumber()
+				_, err = ghub.EditIssue(ctx, client, org, repo, pullRequest.GetNumber(), &github.IssueRequest{
+					Milestone: &milestone,
+				})
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+		}
+
+		// Remove the in progress label added by issue start
+		if inProgressLabel != "" {
+			for _, label := range issue.Labels {
+				if label.GetName() != inProgressLabel {
+					continue
+				}
+				err = ghub.RemoveLabelsFromIssue(ctx, client, issueOrg, issueRepo, issue.GetNumber(), []string{inProgressLabel})
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Printf("Removed label %q from issue #%d\n", inProgressLabel, issue.GetNumber())
+			}
+		}
+	},
+}
+
+func init() {
+	IssueFinishCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository the issue belongs to")
+}
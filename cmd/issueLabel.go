@@ -0,0 +1,134 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// IssueLabelCmd represents the issue label command
+var IssueLabelCmd = &cobra.Command{
+	Use:   "label [issue number...]",
+	Short: "Add labels to issues",
+	Long:  `Add labels to one or more issues`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// A label is required
+		if len(LabelsFlag) == 0 {
+			fmt.Println(color.RedString("ERROR: %s", "A --label is required"))
+			os.Exit(1)
+		}
+
+		for _, number := range numbers {
+			err := ghub.AddLabelsToIssue(ctx, client, org, repo, number, LabelsFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Added labels %s on issue #%d\n", strings.Join(LabelsFlag, ", "), number)
+		}
+	},
+}
+
+// IssueUnlabelCmd represents the issue unlabel command
+var IssueUnlabelCmd = &cobra.Command{
+	Use:   "unlabel [issue number...]",
+	Short: "Remove labels from issues",
+	Long:  `Remove labels from one or more issues`,
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo, numbers := issueTargets(ctx, client, gitHubToken, args)
+
+		// A label is required
+		if len(LabelsFlag) == 0 {
+			fmt.Println(color.RedString("ERROR: %s", "A --label is required"))
+			os.Exit(1)
+		}
+
+		for _, number := range numbers {
+			err := ghub.RemoveLabelsFromIssue(ctx, client, org, repo, number, LabelsFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Removed labels %s on issue #%d\n", strings.Join(LabelsFlag, ", "), number)
+		}
+	},
+}
+
+func init() {
+	IssueLabelCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueLabelCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueLabelCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Label to add, can be repeated")
+	IssueUnlabelCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository of the issues")
+	IssueUnlabelCmd.Flags().StringVarP(&QueryFlag, "query", "q", "", "Work on the issues matching a search query, like 'is:open label:bug'")
+	IssueUnlabelCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Label to remove, can be repeated")
+}
@@ -18,16 +18,55 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/google/go-github/github"
 	ghub "github.com/repejota/git-hub"
 	"github.com/spf13/cobra"
 )
 
+// StateFlag ...
+var StateFlag string
+
+// LabelsFlag ...
+var LabelsFlag []string
+
+// AssigneeFlag ...
+var AssigneeFlag string
+
+// MineFlag ...
+var MineFlag bool
+
+// MilestoneFlag ...
+var MilestoneFlag string
+
+// AuthorFlag ...
+var AuthorFlag string
+
+// MentionedFlag ...
+var MentionedFlag string
+
+// SinceFlag ...
+var SinceFlag string
+
+// SortFlag ...
+var SortFlag string
+
+// DirectionFlag ...
+var DirectionFlag string
+
+// LimitFlag ...
+var LimitFlag int
+
+// PullRequestsFlag ...
+var PullRequestsFlag string
+
 // IssueListCmd represents the issue list command
 var IssueListCmd = &cobra.Command{
 	Use:   "list",
@@ -68,19 +107,80 @@ var IssueListCmd = &cobra.Command{
 			repository = Repository
 		}
 
+		// Filters
+		options := &ghub.IssueListOptions{
+			IssueListByRepoOptions: github.IssueListByRepoOptions{
+				State:     StateFlag,
+				Labels:    LabelsFlag,
+				Assignee:  AssigneeFlag,
+				Milestone: MilestoneFlag,
+				Creator:   AuthorFlag,
+				Mentioned: MentionedFlag,
+				Sort:      SortFlag,
+				Direction: DirectionFlag,
+			},
+			Limit:        LimitFlag,
+			PullRequests: PullRequestsFlag,
+		}
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+
+		// --mine
+		if MineFlag {
+			user, err := ghub.GetAuthenticatedUser(ctx, client)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			options.Assignee = user.GetLogin()
+		}
+
+		// --since
+		if SinceFlag != "" {
+			since, err := ghub.ParseSince(SinceFlag, time.Now())
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			options.Since = since
+		}
+
+		// --pull-requests
+		switch PullRequestsFlag {
+		case ghub.PullRequestsInclude, ghub.PullRequestsExclude, ghub.PullRequestsOnly:
+		default:
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid pull requests filter", PullRequestsFlag))
+			os.Exit(1)
+		}
+
 		// List issues by repo
-		issues, err := ghub.ListIssuesByRepo(repository)
+		issues, err := ghub.ListIssuesByRepo(ctx, client, repository, options)
 		if err != nil {
 			log.Fatal(err)
 		}
 
 		// Render issues by repo
+		summaries := []*ghub.IssueSummary{}
 		for _, issue := range issues {
-			fmt.Printf("#%d - %s - %s\n", *issue.Number, *issue.Title, *issue.HTMLURL)
+			summaries = append(summaries, ghub.NewIssueSummary(issue))
 		}
+		render(summaries)
 	},
 }
 
 func init() {
 	IssueListCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the issues from")
+	IssueListCmd.Flags().StringVarP(&StateFlag, "state", "s", "open", "Filter by state: open, closed or all")
+	IssueListCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Filter by label, can be repeated")
+	IssueListCmd.Flags().StringVarP(&AssigneeFlag, "assignee", "a", "", "Filter by assignee, none or * for any")
+	IssueListCmd.Flags().BoolVarP(&MineFlag, "mine", "", false, "Filter issues assigned to you")
+	IssueListCmd.Flags().StringVarP(&MilestoneFlag, "milestone", "m", "", "Filter by milestone title or number, none or * for any")
+	IssueListCmd.Flags().StringVarP(&AuthorFlag, "author", "", "", "Filter by author")
+	IssueListCmd.Flags().StringVarP(&MentionedFlag, "mentioned", "", "", "Filter by mentioned user")
+	IssueListCmd.Flags().StringVarP(&SinceFlag, "since", "", "", "Filter updated since a date (2006-01-02) or an age (7d)")
+	IssueListCmd.Flags().StringVarP(&SortFlag, "sort", "", "created", "Sort by created, updated or comments")
+	IssueListCmd.Flags().StringVarP(&DirectionFlag, "direction", "", "desc", "Sort direction: asc or desc")
+	IssueListCmd.Flags().IntVarP(&LimitFlag, "limit", "L", 0, "Maximum number of issues to list, 0 lists all")
+	IssueListCmd.Flags().StringVarP(&PullRequestsFlag, "pull-requests", "", ghub.PullRequestsInclude, "Pull requests: include, exclude or only")
 }
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -25,17 +26,28 @@ import (
 	"strconv"
 
 	"github.com/fatih/color"
+	"github.com/google/go-github/github"
 	ghub "github.com/repejota/git-hub"
 	"github.com/repejota/git-hub/automation"
 	"github.com/spf13/cobra"
 )
 
+// ForceFlag ...
+var ForceFlag bool
+
+// DraftPullRequestFlag ...
+var DraftPullRequestFlag bool
+
+// WorktreeFlag ...
+var WorktreeFlag bool
+
 // IssueStartCmd represents the issue start command
 var IssueStartCmd = &cobra.Command{
-	Use:   "start [issue number]",
-	Short: "Start an issue",
-	Long:  `Start working on an issue`,
-	Args:  cobra.ExactArgs(1),
+	Use:         "start [issue number]",
+	Short:       "Start an issue",
+	Long:        `Start working on an issue, with no issue number an open issue is picked interactively`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{completionAnnotation: completeIssues},
 	Run: func(cmd *cobra.Command, args []string) {
 		log.SetFlags(0)
 
@@ -56,17 +68,15 @@ var IssueStartCmd = &cobra.Command{
 		}
 		log.Printf("GitHub Token: %s\n", gitHubToken)
 
-		// An issue id is required
-		if len(args) == 0 {
-			fmt.Println(color.RedString("ERROR: %s", "An issue ID is required"))
-			os.Exit(1)
-		}
-
 		// It should be an integer
-		issueID, err := strconv.Atoi(args[0])
-		if err != nil {
-			fmt.Println(color.RedString("ERROR: %s %q", "Invalid issue ID", args[0]))
-			os.Exit(1)
+		issueID := 0
+		if len(args) > 0 {
+			number, err := strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s %q", "Invalid issue ID", args[0]))
+				os.Exit(1)
+			}
+			issueID = number
 		}
 
 		// Open repository
@@ -84,15 +94,20 @@ var IssueStartCmd = &cobra.Command{
 		}
 
 		// Get User
-		user, err := ghub.GetAuthenticatedUser()
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		user, err := ghub.GetAuthenticatedUser(ctx, client)
 		if err != nil {
 			fmt.Println(color.RedString("ERROR: %s", err.Error()))
 			os.Exit(1)
 		}
 
-		// Get Issue
+		// Get Issue, with no issue ID one is picked
 		org, repo := ghub.ParseRepositoryFullName(repository)
-		issue, err := ghub.GetIssue(org, repo, issueID)
+		if issueID == 0 {
+			issueID = pickIssue(ctx, client, org, repo)
+		}
+		issue, err := ghub.GetIssue(ctx, client, org, repo, issueID)
 		if err != nil {
 			fmt.Println(color.RedString("ERROR: %s", err.Error()))
 			os.Exit(1)
@@ -104,37 +119,222 @@ var IssueStartCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// Warn if somebody else is already working on the issue
+		login := user.GetLogin()
+		assigned := ghub.IsAssignedTo(issue, login)
+		if !assigned && len(issue.Assignees) > 0 && !ForceFlag {
+			fmt.Println(color.YellowString("WARNING: Issue #%d is already assigned to %s, use --force to start it anyway", issue.GetNumber(), issue.Assignees[0].GetLogin()))
+			os.Exit(1)
+		}
+
 		// Assign User to the Issue
-		err = ghub.AssignUserToIssue(org, repo, user, issue)
+		if assigned {
+			fmt.Println("Issue already assigned to", login)
+		} else {
+			err = ghub.AssignUserToIssue(ctx, client, org, repo, user, issue)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Println("Assigned issue to", login)
+		}
+
+		// Label the issue as in progress, if configured
+		inProgressLabel, err := automation.GetConfig("inProgressLabel")
 		if err != nil {
 			fmt.Println(color.RedString("ERROR: %s", err.Error()))
 			os.Exit(1)
 		}
-		fmt.Println("Assigned issue to", user.GetLogin())
+		if inProgressLabel != "" {
+			err = ghub.AddLabelsToIssue(ctx, client, org, repo, issue.GetNumber(), []string{inProgressLabel})
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Labeled issue as %q\n", inProgressLabel)
+		}
 
-		// Create local issue branch
-		issueBranchName := fmt.Sprintf("issue/%s", ghub.SlugifyIssue(issue))
+		// Resume an existing issue branch, even if the title has changed
+		repositorySlug := ""
 		if Repository != "" {
-			issueBranchName = fmt.Sprintf("issue/%s-%s", ghub.SlugifyRepository(repository), ghub.SlugifyIssue(issue))
+			repositorySlug = ghub.SlugifyRepository(repository)
 		}
-
-		out, err := automation.CreateLocalGitBranch(issueBranchName)
+		_, err = automation.FetchOrigin()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		localBranchNames, err := automation.ListLocalBranches()
 		if err != nil {
 			fmt.Println(color.RedString("ERROR: %s", err.Error()))
 			os.Exit(1)
 		}
+		remoteBranchNames, err := automation.ListRemoteBranches()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		if issueBranchName := ghub.FindIssueBranch(localBranchNames, issueID, repositorySlug); issueBranchName != "" {
+			out := ""
+			if WorktreeFlag {
+				issueWorktree(issue, issueBranchName, "")
+			} else {
+				out, err = automation.GoGitBranch(issueBranchName)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			fmt.Println("Resuming local branch", issueBranchName)
+			fmt.Println(out)
+
+			// Track the remote branch, or publish the local one
+			onRemote := false
+			for _, remoteBranchName := range remoteBranchNames {
+				if remoteBranchName == issueBranchName {
+					onRemote = true
+				}
+			}
+			if onRemote {
+				out, err = automation.SetUpstreamBranch(issueBranchName)
+			} else {
+				out, err = automation.PushLocalBranchToOrigin(issueBranchName)
+			}
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Println(out)
+			return
+		}
+		if issueBranchName := ghub.FindIssueBranch(remoteBranchNames, issueID, repositorySlug); issueBranchName != "" {
+			out := ""
+			if WorktreeFlag {
+				issueWorktree(issue, issueBranchName, "")
+			} else {
+				out, err = automation.CheckoutRemoteBranch(issueBranchName)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+			fmt.Println("Resuming remote branch", issueBranchName)
+			fmt.Println(out)
+			return
+		}
+
+		// Create local issue branch
+		issueBranchName := ghub.IssueBranchName(issue, Repository)
+
+		// --worktree
+		// Working trees start from the just fetched default branch
+		out := ""
+		if WorktreeFlag {
+			issueWorktree(issue, issueBranchName, "origin/"+r.GitHubRepository.GetDefaultBranch())
+		} else {
+			out, err = automation.CreateLocalGitBranch(issueBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+		}
 		fmt.Println("Creating local branch", issueBranchName)
 		fmt.Println(out)
 
+		// Branches started by stack start are stacked on the current one
+		baseBranchName := r.GitHubRepository.GetDefaultBranch()
+		aheadBase := "origin/" + baseBranchName
+		if stackParentBranchName != "" {
+			err = automation.SetBranchParent(issueBranchName, stackParentBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Stacked %s on %s\n", issueBranchName, stackParentBranchName)
+			baseBranchName = stackParentBranchName
+			aheadBase = stackParentBranchName
+		}
+
+		// --draft-pr
+		// An empty commit lets the draft pull request be opened right away
+		draftPullRequest := DraftPullRequestFlag
+		if !draftPullRequest {
+			configured, err := automation.GetConfig("draftPullRequest")
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			draftPullRequest = configured == "true"
+		}
+		if draftPullRequest {
+			ahead, _, err := automation.GetAheadBehind(aheadBase, issueBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			if ahead == 0 {
+				message := fmt.Sprintf("Start %s", ghub.IssueReference(issue.GetNumber(), Repository, r.GitHubRepository.GetFullName()))
+				_, err = automation.CommitEmpty(message)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+			}
+		}
+
 		// Push local release branch to origin
 		out, err = automation.PushLocalBranchToOrigin(issueBranchName)
 		if err != nil {
 			log.Fatal(err)
 		}
 		fmt.Println(out)
+
+		if draftPullRequest {
+			// The base of the pull request must be on origin
+			parentOnRemote := stackParentBranchName == ""
+			for _, remoteBranchName := range remoteBranchNames {
+				if remoteBranchName == stackParentBranchName {
+					parentOnRemote = true
+				}
+			}
+			if !parentOnRemote {
+				out, err = automation.PushLocalBranchToOrigin(stackParentBranchName)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println(out)
+			}
+			org, repo := ghub.ParseRepositoryFullName(r.GitHubRepository.GetFullName())
+			pullRequest, err := ghub.CreateIssueDraftPullRequest(ctx, client, org, repo, issue, Repository, issueBranchName, baseBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Printf("Opened draft pull request #%d %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+		}
 	},
 }
 
 func init() {
 	IssueStartCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the issues from")
+	IssueStartCmd.Flags().BoolVarP(&ForceFlag, "force", "f", false, "Start the issue even if it is assigned to somebody else")
+	IssueStartCmd.Flags().BoolVarP(&WorktreeFlag, "worktree", "w", false, "Work on the issue on its own working tree")
+	IssueStartCmd.Flags().BoolVarP(&DraftPullRequestFlag, "draft-pr", "", false, "Open a draft pull request for the issue")
+}
+
+// issueWorktree goes to the working tree of an issue branch, adding it if
+// there is none, so the following git commands run there
+func issueWorktree(issue *github.Issue, branchName string, startPoint string) {
+	path, err := ghub.AddIssueWorktree(issue, branchName, startPoint)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	err = os.Chdir(path)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	fmt.Println("Working tree", path)
 }
@@ -0,0 +1,184 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/output"
+	"github.com/spf13/cobra"
+)
+
+// CommentsFlag ...
+var CommentsFlag bool
+
+// IssueViewCmd represents the issue view command
+var IssueViewCmd = &cobra.Command{
+	Use:         "view [issue number]",
+	Short:       "View an issue",
+	Long:        `View an issue with its labels, assignees, linked pull requests and optionally its comments, with no issue number an open issue is picked interactively`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{completionAnnotation: completeIssues},
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo := issueRepository(gitHubToken)
+
+		// It should be an integer, with no issue ID one is picked
+		issueID := 0
+		if len(args) == 0 {
+			issueID = pickIssue(ctx, client, org, repo)
+		} else {
+			number, err := strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s %q", "Invalid issue ID", args[0]))
+				os.Exit(1)
+			}
+			issueID = number
+		}
+
+		// Get Issue
+		issue, err := ghub.GetIssue(ctx, client, org, repo, issueID)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		pullRequests, err := ghub.ListLinkedPullRequests(ctx, client, org, repo, issueID)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		var buf bytes.Buffer
+		bold := color.New(color.Bold)
+
+		// Header
+		fmt.Fprintln(&buf, bold.Sprintf("#%d %s", issue.GetNumber(), issue.GetTitle()))
+		state := color.GreenString(issue.GetState())
+		if issue.GetState() != "open" {
+			state = color.RedString(issue.GetState())
+		}
+		fmt.Fprintf(&buf, "%s • opened by @%s %s • %d comments\n", state, issue.GetUser().GetLogin(), ghub.HumanizeTime(issue.GetCreatedAt(), now), issue.GetComments())
+		summary := ghub.NewIssueSummary(issue)
+		if len(summary.Labels) > 0 {
+			fmt.Fprintf(&buf, "Labels: %s\n", strings.Join(summary.Labels, ", "))
+		}
+		if len(summary.Assignees) > 0 {
+			fmt.Fprintf(&buf, "Assignees: @%s\n", strings.Join(summary.Assignees, ", @"))
+		}
+		if issue.Milestone != nil {
+			fmt.Fprintf(&buf, "Milestone: %s\n", issue.GetMilestone().GetTitle())
+		}
+		for _, pullRequest := range pullRequests {
+			fmt.Fprintf(&buf, "Linked pull request: #%d %s (%s)\n", pullRequest.GetNumber(), pullRequest.GetTitle(), pullRequest.GetState())
+		}
+		fmt.Fprintln(&buf, issue.GetHTMLURL())
+
+		// Body
+		fmt.Fprintln(&buf)
+		body := issue.GetBody()
+		if body == "" {
+			body = "No description provided."
+		}
+		fmt.Fprintln(&buf, output.RenderMarkdown(body))
+
+		// --comments
+		// Comments and timeline events in chronological order
+		if CommentsFlag {
+			comments, err := ghub.ListIssueComments(ctx, client, org, repo, issueID)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			events, err := ghub.ListIssueTimeline(ctx, client, org, repo, issueID)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+
+			type entry struct {
+				createdAt time.Time
+				text      string
+			}
+			entries := []entry{}
+			for _, comment := range comments {
+				header := bold.Sprintf("@%s commented %s", comment.GetUser().GetLogin(), ghub.HumanizeTime(comment.GetCreatedAt(), now))
+				text := fmt.Sprintf("%s\n%s", header, output.RenderMarkdown(comment.GetBody()))
+				entries = append(entries, entry{comment.GetCreatedAt(), text})
+			}
+			for _, event := range events {
+				description := ghub.DescribeTimelineEvent(event)
+				if description == "" {
+					continue
+				}
+				text := color.New(color.Faint).Sprintf("%s %s", description, ghub.HumanizeTime(event.GetCreatedAt(), now))
+				entries = append(entries, entry{event.GetCreatedAt(), text})
+			}
+			sort.SliceStable(entries, func(i, j int) bool {
+				return entries[i].createdAt.Before(entries[j].createdAt)
+			})
+			for _, entry := range entries {
+				fmt.Fprintln(&buf)
+				fmt.Fprintln(&buf, entry.text)
+			}
+		}
+
+		err = output.Page(buf.String())
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	IssueViewCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the issue from")
+	IssueViewCmd.Flags().BoolVarP(&CommentsFlag, "comments", "c", false, "Show comments and timeline events")
+}
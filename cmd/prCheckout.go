@@ -0,0 +1,156 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// PullRequestCheckoutCmd represents the pr checkout command
+var PullRequestCheckoutCmd = &cobra.Command{
+	Use:   "checkout [pull request number]",
+	Short: "Check out a pull request",
+	Long:  `Check out the head of a pull request on a local branch, also for pull requests from forks`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// It should be an integer
+		number, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid pull request number", args[0]))
+			os.Exit(1)
+		}
+
+		// Get Pull Request
+		org, repo := issueRepository(gitHubToken)
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		pullRequest, err := ghub.GetPullRequest(ctx, client, org, repo, number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		branchName := ghub.PullRequestBranchName(pullRequest)
+
+		// Fetch refs/pull/<number>/head, that also has the commits of forks,
+		// into a new branch or fast forward the existing one
+		localBranchNames, err := automation.ListLocalBranches()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		exists := false
+		for _, localBranchName := range localBranchNames {
+			if localBranchName == branchName {
+				exists = true
+			}
+		}
+		if exists {
+			_, err = automation.GoGitBranch(branchName)
+			if err == nil {
+				_, err = automation.FetchPullRequest(number, "")
+			}
+			if err == nil {
+				_, err = automation.MergeFastForward("FETCH_HEAD")
+			}
+		} else {
+			_, err = automation.FetchPullRequest(number, branchName)
+			if err == nil {
+				_, err = automation.GoGitBranch(branchName)
+			}
+		}
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Track the head branch, on the fork remote if we can push to it, or
+		// the pull request ref otherwise
+		head := pullRequest.GetHead()
+		remote := "origin"
+		mergeRef := fmt.Sprintf("refs/pull/%d/head", number)
+		if head.Repo != nil && head.GetRepo().GetFullName() == pullRequest.GetBase().GetRepo().GetFullName() {
+			mergeRef = "refs/heads/" + head.GetRef()
+		} else if head.Repo != nil && head.GetRepo().GetPermissions()["push"] {
+			remote = head.GetRepo().GetOwner().GetLogin()
+			remoteURL, err := automation.GetRemoteURL(remote)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			if remoteURL == "" {
+				// Use the same protocol as origin
+				originURL, err := automation.GetRemoteURL("origin")
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				remoteURL = head.GetRepo().GetCloneURL()
+				if strings.HasPrefix(originURL, "git@") {
+					remoteURL = head.GetRepo().GetSSHURL()
+				}
+				_, err = automation.AddRemote(remote, remoteURL)
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Printf("Added remote %s %s\n", remote, remoteURL)
+			}
+			mergeRef = "refs/heads/" + head.GetRef()
+		}
+		err = automation.SetBranchUpstreamRef(branchName, remote, mergeRef)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Printf("Checked out pull request #%d on branch %s tracking %s %s\n", number, branchName, remote, mergeRef)
+	},
+}
+
+func init() {
+	PullRequestCheckoutCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the pull request from")
+}
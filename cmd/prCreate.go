@@ -0,0 +1,210 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/github"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// BaseFlag ...
+var BaseFlag string
+
+// DraftFlag ...
+var DraftFlag bool
+
+// ReviewersFlag ...
+var ReviewersFlag []string
+
+// PullRequestCreateCmd represents the pr create command
+var PullRequestCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a pull request",
+	Long:  `Push the current branch if needed and open a pull request from it, by default into the repository default branch`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// Open repository
+		path := "."
+		repository, err := ghub.OpenRepository(path, gitHubToken)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// The head is the current branch
+		headBranchName, err := automation.GetCurrentBranch()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// --base
+		baseBranchName := repository.GitHubRepository.GetDefaultBranch()
+		if BaseFlag != "" {
+			baseBranchName = BaseFlag
+		}
+		if headBranchName == baseBranchName {
+			fmt.Println(color.RedString("ERROR: You are on the base branch %q", baseBranchName))
+			os.Exit(1)
+		}
+
+		// Push the branch if it is not on origin or has new commits
+		remoteBranchNames, err := automation.ListRemoteBranches()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		pushed := false
+		for _, remoteBranchName := range remoteBranchNames {
+			if remoteBranchName == headBranchName {
+				pushed = true
+			}
+		}
+		if pushed {
+			ahead, _, err := automation.GetAheadBehind("origin/"+headBranchName, headBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			pushed = ahead == 0
+		}
+		if !pushed {
+			out, err := automation.PushLocalBranchToOrigin(headBranchName)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			fmt.Println("Pushed branch", headBranchName)
+			fmt.Println(out)
+		}
+
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo := ghub.ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+
+		// Only one pull request can be open for a branch
+		pullRequest, err := ghub.FindOpenPullRequest(ctx, client, org, repo, headBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		if pullRequest != nil {
+			fmt.Printf("Pull request #%d already open %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+			return
+		}
+
+		// Default title and body from the template or the commits
+		subjects, err := automation.GetCommitSubjects("origin/"+baseBranchName, headBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		title := TitleFlag
+		if title == "" {
+			title = ghub.PullRequestTitle(headBranchName, subjects)
+		}
+		body := BodyFlag
+		if body == "-" {
+			body = readBody(body, "")
+		}
+		if body == "" {
+			body, err = ghub.LoadPullRequestTemplate(path)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+		}
+		if body == "" {
+			body = ghub.PullRequestBody(subjects)
+		}
+
+		// Create the pull request
+		newPullRequest := &github.NewPullRequest{
+			Title: &title,
+			Head:  &headBranchName,
+			Base:  &baseBranchName,
+			Body:  &body,
+		}
+		if DraftFlag {
+			pullRequest, err = ghub.CreateDraftPullRequest(ctx, client, org, repo, newPullRequest)
+		} else {
+			pullRequest, err = ghub.CreatePullRequest(ctx, client, org, repo, newPullRequest)
+		}
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// --label
+		if len(LabelsFlag) > 0 {
+			err = ghub.AddLabelsToIssue(ctx, client, org, repo, pullRequest.GetNumber(), LabelsFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+		}
+
+		// --reviewer
+		if len(ReviewersFlag) > 0 {
+			err = ghub.RequestReviewers(ctx, client, org, repo, pullRequest.GetNumber(), ReviewersFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+		}
+
+		fmt.Printf("Created pull request #%d %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+	},
+}
+
+func init() {
+	PullRequestCreateCmd.Flags().StringVarP(&BaseFlag, "base", "B", "", "Branch to merge into, the default branch by default")
+	PullRequestCreateCmd.Flags().BoolVarP(&DraftFlag, "draft", "d", false, "Create the pull request as a draft")
+	PullRequestCreateCmd.Flags().StringVarP(&TitleFlag, "title", "t", "", "Pull request title, by default from the commits or the branch name")
+	PullRequestCreateCmd.Flags().StringVarP(&BodyFlag, "body", "b", "", "Pull request body, \"-\" reads it from stdin, by default the pull request template or the commits")
+	PullRequestCreateCmd.Flags().StringSliceVarP(&ReviewersFlag, "reviewer", "R", nil, "Request a review from a user, can be repeated")
+	PullRequestCreateCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Add a label, can be repeated")
+}
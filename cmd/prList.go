@@ -0,0 +1,106 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// ReviewRequestedFlag ...
+var ReviewRequestedFlag string
+
+// PullRequestListCmd represents the pr list command
+var PullRequestListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pull requests",
+	Long:  `List repository pull requests`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// --state
+		switch StateFlag {
+		case ghub.PullRequestStateOpen, ghub.PullRequestStateClosed, ghub.PullRequestStateMerged, ghub.PullRequestStateAll:
+		default:
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid state", StateFlag))
+			os.Exit(1)
+		}
+
+		// Filters
+		options := &ghub.PullRequestListOptions{
+			State:           StateFlag,
+			Author:          AuthorFlag,
+			ReviewRequested: ReviewRequestedFlag,
+			Labels:          LabelsFlag,
+			Base:            BaseFlag,
+			Limit:           LimitFlag,
+		}
+
+		// List pull requests
+		org, repo := issueRepository(gitHubToken)
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		pullRequests, err := ghub.ListPullRequests(ctx, client, org, repo, options)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// Render pull requests
+		summaries := []*ghub.IssueSummary{}
+		for _, pullRequest := range pullRequests {
+			summaries = append(summaries, ghub.NewIssueSummary(pullRequest))
+		}
+		render(summaries)
+	},
+}
+
+func init() {
+	PullRequestListCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the pull requests from")
+	PullRequestListCmd.Flags().StringVarP(&StateFlag, "state", "s", ghub.PullRequestStateOpen, "Filter by state: open, closed, merged or all")
+	PullRequestListCmd.Flags().StringVarP(&AuthorFlag, "author", "", "", "Filter by author")
+	PullRequestListCmd.Flags().StringVarP(&ReviewRequestedFlag, "reviewer", "", "", "Filter by user or team requested to review")
+	PullRequestListCmd.Flags().StringSliceVarP(&LabelsFlag, "label", "l", nil, "Filter by label, can be repeated")
+	PullRequestListCmd.Flags().StringVarP(&BaseFlag, "base", "B", "", "Filter by base branch")
+	PullRequestListCmd.Flags().IntVarP(&LimitFlag, "limit", "L", 0, "Maximum number of pull requests to list, 0 lists all")
+}
@@ -0,0 +1,220 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// MergeFlag ...
+var MergeFlag bool
+
+// SquashFlag ...
+var SquashFlag bool
+
+// RebaseFlag ...
+var RebaseFlag bool
+
+// DeleteBranchFlag ...
+var DeleteBranchFlag bool
+
+// AutoFlag ...
+var AutoFlag bool
+
+// PullRequestMergeCmd represents the pr merge command
+var PullRequestMergeCmd = &cobra.Command{
+	Use:   "merge [pull request number]",
+	Short: "Merge a pull request",
+	Long:  `Merge a pull request once it has the approvals and checks its base branch requires, then update the local default branch`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// It should be an integer
+		number, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid pull request number", args[0]))
+			os.Exit(1)
+		}
+
+		// --merge, --squash or --rebase
+		mergeMethod := ghub.MergeMethodMerge
+		methods := 0
+		if MergeFlag {
+			methods++
+		}
+		if SquashFlag {
+			mergeMethod = ghub.MergeMethodSquash
+			methods++
+		}
+		if RebaseFlag {
+			mergeMethod = ghub.MergeMethodRebase
+			methods++
+		}
+		if methods > 1 {
+			fmt.Println(color.RedString("ERROR: %s", "Only one of --merge, --squash or --rebase can be given"))
+			os.Exit(1)
+		}
+
+		// Open repository
+		path := "."
+		repository, err := ghub.OpenRepository(path, gitHubToken)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+
+		// Get Pull Request
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		org, repo := ghub.ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+		pullRequest, err := ghub.GetPullRequest(ctx, client, org, repo, number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		if pullRequest.GetState() != "open" {
+			fmt.Println(color.RedString("ERROR: Pull request #%d is %s", number, pullRequest.GetState()))
+			os.Exit(1)
+		}
+
+		// --auto
+		// Wait for the approvals and checks required by the base branch, or
+		// require them right away
+		if AutoFlag {
+			fmt.Println("Waiting for approvals and checks on", pullRequest.GetHTMLURL())
+			pullRequest, err = ghub.WaitForPullRequest(ctx, client, org, repo, number, 30*time.Second, TimeoutFlag)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+		} else {
+			requirements, err := ghub.GetMergeRequirements(ctx, client, org, repo, pullRequest.GetBase().GetRef())
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			status, err := ghub.GetPullRequestStatus(ctx, client, org, repo, pullRequest)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			blockers := status.MergeBlockers(requirements)
+			if len(blockers) > 0 {
+				fmt.Println(color.RedString("ERROR: Pull request #%d can not be merged, %s, use --auto to wait for them", number, strings.Join(blockers, ", ")))
+				os.Exit(1)
+			}
+		}
+
+		// Merge the pull request
+		commitTitle, commitMessage := "", ""
+		if mergeMethod == ghub.MergeMethodSquash {
+			commitTitle, commitMessage = ghub.SquashCommitMessage(pullRequest)
+		}
+		sha, err := ghub.MergePullRequest(ctx, client, org, repo, pullRequest, commitTitle, commitMessage, mergeMethod)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Printf("Merged pull request #%d as %s\n", number, sha)
+
+		// --delete-branch
+		// Delete the remote branch, only on this repository, and the local one
+		if DeleteBranchFlag {
+			head := pullRequest.GetHead()
+			if head.GetRepo().GetFullName() == repository.GitHubRepository.GetFullName() {
+				_, err = automation.DeleteRemoteBranch(head.GetRef())
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println("Deleted remote branch", head.GetRef())
+			}
+			localBranchName := ghub.PullRequestBranchName(pullRequest)
+			localBranchNames, err := automation.ListLocalBranches()
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			for _, branchName := range localBranchNames {
+				if branchName != localBranchName {
+					continue
+				}
+				currentBranchName, err := automation.GetCurrentBranch()
+				if err == nil && currentBranchName == localBranchName {
+					_, err = automation.GoGitBranch(defaultBranchName)
+				}
+				if err == nil {
+					_, err = automation.ForceDeleteLocalBranch(localBranchName)
+				}
+				if err != nil {
+					fmt.Println(color.RedString("ERROR: %s", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println("Deleted local branch", localBranchName)
+			}
+		}
+
+		// Fast forward the local default branch
+		_, err = automation.FastForwardBranch(defaultBranchName)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println("Updated local branch", defaultBranchName)
+	},
+}
+
+func init() {
+	PullRequestMergeCmd.Flags().BoolVarP(&MergeFlag, "merge", "m", false, "Merge with a merge commit, the default")
+	PullRequestMergeCmd.Flags().BoolVarP(&SquashFlag, "squash", "s", false, "Squash the commits into one")
+	PullRequestMergeCmd.Flags().BoolVarP(&RebaseFlag, "rebase", "", false, "Rebase the commits onto the base branch")
+	PullRequestMergeCmd.Flags().BoolVarP(&DeleteBranchFlag, "delete-branch", "d", false, "Delete the remote and local branches after merging")
+	PullRequestMergeCmd.Flags().BoolVarP(&AutoFlag, "auto", "", false, "Wait for approvals and checks before merging")
+	PullRequestMergeCmd.Flags().DurationVarP(&TimeoutFlag, "timeout", "", time.Hour, "How long to wait for approvals and checks with --auto")
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/output"
+	"github.com/spf13/cobra"
+)
+
+// PullRequestViewCmd represents the pr view command
+var PullRequestViewCmd = &cobra.Command{
+	Use:   "view [pull request number]",
+	Short: "View a pull request",
+	Long:  `View a pull request with its description, checks, reviews and changed files`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// It should be an integer
+		number, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s %q", "Invalid pull request number", args[0]))
+			os.Exit(1)
+		}
+
+		// Get Pull Request
+		org, repo := issueRepository(gitHubToken)
+		ctx := context.Background()
+		client := ghub.NewGitHubClient(ctx, gitHubToken)
+		pullRequest, err := ghub.GetPullRequest(ctx, client, org, repo, number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		status, err := ghub.GetPullRequestStatus(ctx, client, org, repo, pullRequest)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		reviews, err := ghub.ListLatestReviews(ctx, client, org, repo, number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		files, err := ghub.ListPullRequestFiles(ctx, client, org, repo, number)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		var buf bytes.Buffer
+		bold := color.New(color.Bold)
+
+		// Header
+		fmt.Fprintln(&buf, bold.Sprintf("#%d %s", pullRequest.GetNumber(), pullRequest.GetTitle()))
+		state := color.GreenString(pullRequest.GetState())
+		if pullRequest.GetMerged() {
+			state = color.MagentaString(ghub.PullRequestStateMerged)
+		} else if pullRequest.GetState() != "open" {
+			state = color.RedString(pullRequest.GetState())
+		}
+		fmt.Fprintf(&buf, "%s • @%s wants to merge %d commits into %s from %s • opened %s\n", state, pullRequest.GetUser().GetLogin(), pullRequest.GetCommits(), pullRequest.GetBase().GetRef(), pullRequest.GetHead().GetLabel(), ghub.HumanizeTime(pullRequest.GetCreatedAt(), now))
+		labels := []string{}
+		for _, label := range pullRequest.Labels {
+			labels = append(labels, label.GetName())
+		}
+		if len(labels) > 0 {
+			fmt.Fprintf(&buf, "Labels: %s\n", strings.Join(labels, ", "))
+		}
+
+		// Checks and reviews
+		checks := status.Checks
+		switch checks {
+		case ghub.ChecksSuccess:
+			checks = color.GreenString(checks)
+		case ghub.ChecksPending:
+			checks = color.YellowString(checks)
+		case ghub.ChecksFailure:
+			checks = color.RedString(checks)
+		}
+		fmt.Fprintf(&buf, "Checks: %s\n", checks)
+		fmt.Fprintf(&buf, "Review: %s\n", status.Review())
+		reviewers := []string{}
+		for reviewer := range reviews {
+			reviewers = append(reviewers, reviewer)
+		}
+		sort.Strings(reviewers)
+		for _, reviewer := range reviewers {
+			fmt.Fprintf(&buf, "  @%s %s\n", reviewer, strings.ToLower(strings.Replace(reviews[reviewer], "_", " ", -1)))
+		}
+		fmt.Fprintln(&buf, pullRequest.GetHTMLURL())
+
+		// Body
+		fmt.Fprintln(&buf)
+		body := pullRequest.GetBody()
+		if body == "" {
+			body = "No description provided."
+		}
+		fmt.Fprintln(&buf, output.RenderMarkdown(body))
+
+		// Files changed
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, bold.Sprintf("%d files changed, %s %s", len(files), color.GreenString("+%d", pullRequest.GetAdditions()), color.RedString("-%d", pullRequest.GetDeletions())))
+		for _, file := range files {
+			fmt.Fprintf(&buf, "  %s %s %s\n", file.GetFilename(), color.GreenString("+%d", file.GetAdditions()), color.RedString("-%d", file.GetDeletions()))
+		}
+
+		err = output.Page(buf.String())
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	PullRequestViewCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the pull request from")
+}
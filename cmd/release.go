@@ -21,10 +21,17 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+// PullRequestFlag ...
+var PullRequestFlag bool
+
+// TimeoutFlag ...
+var TimeoutFlag time.Duration
+
 // ReleaseCmd represents the release command
 var ReleaseCmd = &cobra.Command{
 	Use:   "release",
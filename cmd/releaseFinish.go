@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	ghub "github.com/repejota/git-hub"
 	"github.com/spf13/cobra"
@@ -54,6 +55,15 @@ var ReleaseFinishCmd = &cobra.Command{
 
 		repositoryPath := "."
 
-		ghub.ReleaseFinish(repositoryPath, gitHubToken)
+		options := &ghub.ReleaseOptions{
+			PullRequest: PullRequestFlag,
+			Timeout:     TimeoutFlag,
+		}
+		ghub.ReleaseFinish(repositoryPath, gitHubToken, options)
 	},
 }
+
+func init() {
+	ReleaseFinishCmd.Flags().BoolVarP(&PullRequestFlag, "pull-request", "", false, "Merge the release pull request once approved and its checks succeed")
+	ReleaseFinishCmd.Flags().DurationVarP(&TimeoutFlag, "timeout", "", time.Hour, "How long to wait for the release pull request approvals and checks")
+}
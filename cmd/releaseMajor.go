@@ -0,0 +1,74 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// GoModuleFlag ...
+var GoModuleFlag bool
+
+// ReleaseMajorCmd represents the release major command
+var ReleaseMajorCmd = &cobra.Command{
+	Use:   "major",
+	Short: "Do a major release",
+	Long:  `Do a new release bumping the major semver part`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		repositoryPath := "."
+
+		options := &ghub.ReleaseOptions{
+			Major:    true,
+			GoModule: GoModuleFlag,
+			Timeout:  TimeoutFlag,
+		}
+		ghub.ReleaseStart(repositoryPath, gitHubToken, options)
+		ghub.ReleaseFinish(repositoryPath, gitHubToken, options)
+	},
+}
+
+func init() {
+	ReleaseMajorCmd.Flags().BoolVarP(&GoModuleFlag, "go-module", "", false, "Rewrite the Go module path and imports to the new major version")
+	ReleaseMajorCmd.Flags().DurationVarP(&TimeoutFlag, "timeout", "", time.Hour, "How long to wait for the release pull request approvals and checks")
+}
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"time"
 
 	ghub "github.com/repejota/git-hub"
 	"github.com/spf13/cobra"
@@ -54,7 +55,14 @@ var ReleasePatchCmd = &cobra.Command{
 
 		repositoryPath := "."
 
-		ghub.ReleaseStart(repositoryPath, gitHubToken)
-		ghub.ReleaseFinish(repositoryPath, gitHubToken)
+		options := &ghub.ReleaseOptions{
+			Timeout: TimeoutFlag,
+		}
+		ghub.ReleaseStart(repositoryPath, gitHubToken, options)
+		ghub.ReleaseFinish(repositoryPath, gitHubToken, options)
 	},
 }
+
+func init() {
+	ReleasePatchCmd.Flags().DurationVarP(&TimeoutFlag, "timeout", "", time.Hour, "How long to wait for the release pull request approvals and checks")
+}
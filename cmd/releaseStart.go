@@ -26,6 +26,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// VersionFlag ...
+var VersionFlag string
+
 // ReleaseStartCmd represents the release start command
 var ReleaseStartCmd = &cobra.Command{
 	Use:   "start",
@@ -54,6 +57,16 @@ var ReleaseStartCmd = &cobra.Command{
 
 		repositoryPath := "."
 
-		ghub.ReleaseStart(repositoryPath, gitHubToken)
+		options := &ghub.ReleaseOptions{
+			PullRequest: PullRequestFlag,
+			Version:     VersionFlag,
+		}
+		ghub.ReleaseStart(repositoryPath, gitHubToken, options)
 	},
 }
+
+func init() {
+	ReleaseStartCmd.Flags().BoolVarP(&PullRequestFlag, "pull-request", "", false, "Open a pull request from the release branch into the default branch")
+	ReleaseStartCmd.Flags().StringVarP(&VersionFlag, "version", "", "", "Version to release instead of the next one")
+	ReleaseStartCmd.Flags().SetAnnotation("version", completionAnnotation, []string{completeVersions})
+}
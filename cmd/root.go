@@ -18,10 +18,13 @@
 package cmd
 
 import (
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 
+	"github.com/fatih/color"
+	"github.com/repejota/git-hub/output"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +34,12 @@ var VerboseFlag bool
 // GitHubToken ...
 var GitHubToken string
 
+// FormatFlag ...
+var FormatFlag string
+
+// TemplateFlag ...
+var TemplateFlag string
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   "git-hub",
@@ -68,6 +77,22 @@ func init() {
 	cobra.OnInitialize(initConfig)
 	RootCmd.PersistentFlags().BoolVarP(&VerboseFlag, "verbose", "v", false, "enable verbose mode")
 	RootCmd.PersistentFlags().StringVarP(&GitHubToken, "github-token", "", "", "github Auth Token")
+	RootCmd.PersistentFlags().StringVarP(&FormatFlag, "format", "", output.FormatTable, "Output format: table, json, yaml or csv")
+	RootCmd.PersistentFlags().StringVarP(&TemplateFlag, "template", "", "", "Go template rendered for each output item, like '{{.Number}} {{.Title}}'")
+}
+
+// render writes data to stdout using the --format and --template flags
+func render(data interface{}) {
+	options := &output.Options{
+		Format:   FormatFlag,
+		Template: TemplateFlag,
+		TTY:      output.IsTerminal(),
+	}
+	err := output.Render(os.Stdout, options, data)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,57 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// stackParentBranchName is the branch new issue branches are stacked on
+var stackParentBranchName string
+
+// StackStartCmd represents the stack start command
+var StackStartCmd = &cobra.Command{
+	Use:         "start [issue number]",
+	Short:       "Start an issue on top of the current branch",
+	Long:        `Start working on an issue like issue start, on a branch created from the current one that is recorded as its parent, with no issue number an open issue is picked interactively`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{completionAnnotation: completeIssues},
+	Run: func(cmd *cobra.Command, args []string) {
+		currentBranchName, err := automation.GetCurrentBranch()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		// New issue branches are created from the current branch, and
+		// recorded as stacked on it
+		stackParentBranchName = currentBranchName
+		IssueStartCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	StackStartCmd.Flags().StringVarP(&Repository, "repository", "r", "", "Repository to get the issues from")
+	StackStartCmd.Flags().BoolVarP(&ForceFlag, "force", "f", false, "Start the issue even if it is assigned to somebody else")
+	StackStartCmd.Flags().BoolVarP(&DraftPullRequestFlag, "draft-pr", "", false, "Open a draft pull request for the issue")
+}
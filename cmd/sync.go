@@ -0,0 +1,90 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/spf13/cobra"
+)
+
+// SyncCmd represents the sync command
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync workflow branches with the default branch",
+	Long:  `Fast forward the default branch and merge it into, or rebase onto it, the local feature and issue branches, stopping on the first conflict and restoring the current branch and uncommitted changes`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// --merge or --rebase
+		options := &ghub.SyncOptions{}
+		if MergeFlag && RebaseFlag {
+			fmt.Println(color.RedString("ERROR: %s", "Only one of --merge or --rebase can be given"))
+			os.Exit(1)
+		}
+		if MergeFlag {
+			options.Strategy = ghub.SyncMerge
+		}
+		if RebaseFlag {
+			options.Strategy = ghub.SyncRebase
+		}
+
+		path := "."
+		results, err := ghub.Sync(path, gitHubToken, options)
+		if len(results) > 0 {
+			render(results)
+		}
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		for _, result := range results {
+			if result.Result == ghub.SyncConflicted {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	SyncCmd.Flags().BoolVarP(&MergeFlag, "merge", "", false, "Merge the default branch into the branches")
+	SyncCmd.Flags().BoolVarP(&RebaseFlag, "rebase", "", false, "Rebase the branches onto the default branch")
+}
@@ -18,11 +18,13 @@
 package cmd
 
 import (
-	"fmt"
-
+	ghub "github.com/repejota/git-hub"
 	"github.com/spf13/cobra"
 )
 
+// AppVersion is the application version information
+var AppVersion = &ghub.VersionInfo{}
+
 // VersionCmd represents the version command
 var VersionCmd = &cobra.Command{
 	Use:   "version",
@@ -30,6 +32,6 @@ var VersionCmd = &cobra.Command{
 	Long:  `Show application version information`,
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("%s %s", "git-hub", RootCmd.Version)
+		render(AppVersion)
 	},
 }
@@ -0,0 +1,121 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	ghub "github.com/repejota/git-hub"
+	"github.com/repejota/git-hub/automation"
+	"github.com/spf13/cobra"
+)
+
+// WorktreeCleanCmd represents the worktree clean command
+var WorktreeCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove the working trees of finished issues",
+	Long:  `Remove the working trees whose issue is closed or whose branch is merged, keeping the ones with uncommitted changes`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.SetFlags(0)
+
+		// by default logging is off
+		log.SetOutput(ioutil.Discard)
+
+		// --verbose
+		// enable logging if verbose mode
+		if VerboseFlag {
+			log.SetOutput(os.Stdout)
+		}
+
+		// --github-token
+		// Get the GitHub Token from env or from flag
+		gitHubToken := os.Getenv("GITHUB_TOKEN")
+		if GitHubToken != "" {
+			gitHubToken = GitHubToken
+		}
+		log.Printf("GitHub Token: %s\n", gitHubToken)
+
+		// The current working tree is kept
+		currentPath, err := os.Getwd()
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+		currentPath, err = filepath.EvalSymlinks(currentPath)
+		if err != nil {
+			fmt.Println(color.RedString("ERROR: %s", err.Error()))
+			os.Exit(1)
+		}
+
+		worktrees := []*ghub.WorktreeReport{}
+		for _, worktree := range listWorktrees(gitHubToken) {
+			if worktree.Reason == "" || worktree.Path == currentPath {
+				continue
+			}
+			worktrees = append(worktrees, worktree)
+			fmt.Printf("%s %s (%s)\n", worktree.Path, worktree.Branch, worktree.Reason)
+		}
+		if len(worktrees) == 0 {
+			fmt.Println("No working trees to remove")
+			return
+		}
+
+		// --dry-run
+		if DryRunFlag {
+			return
+		}
+
+		// --yes
+		if !YesFlag {
+			confirmed, err := ghub.Confirm(fmt.Sprintf("Remove %d working trees?", len(worktrees)))
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: %s", err.Error()))
+				os.Exit(1)
+			}
+			if !confirmed {
+				os.Exit(1)
+			}
+		}
+
+		// Working trees with changes are kept, not failing the others
+		failed := false
+		for _, worktree := range worktrees {
+			_, err := automation.RemoveWorktree(worktree.Path)
+			if err != nil {
+				fmt.Println(color.RedString("ERROR: Can not remove %s, it may have uncommitted changes", worktree.Path))
+				failed = true
+				continue
+			}
+			fmt.Println(color.GreenString("Removed %s", worktree.Path))
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	WorktreeCleanCmd.Flags().BoolVarP(&DryRunFlag, "dry-run", "n", false, "List the working trees to remove without removing them")
+	WorktreeCleanCmd.Flags().BoolVarP(&YesFlag, "yes", "y", false, "Do not ask for confirmation")
+}
@@ -18,11 +18,14 @@
 package ghub
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
+	"github.com/google/go-github/github"
 	"github.com/repejota/git-hub/automation"
 )
 
@@ -53,3 +56,210 @@ func FeatureStart(repositoryPath string, gitHubToken string, featureTitle string
 	}
 	fmt.Println(out)
 }
+
+// Feature merge strategies
+const (
+	FeatureMergeNoFF   = "merge"
+	FeatureMergeSquash = "squash"
+	FeatureMergeRebase = "rebase"
+)
+
+// FeatureOptions ...
+type FeatureOptions struct {
+	// Strategy is how the feature branch gets into the default branch,
+	// merge with a merge commit, squash or rebase. It defaults to the
+	// git-hub.featureMerge setting or merge.
+	Strategy string
+	// PullRequest opens a pull request instead of merging, also enabled
+	// with git-hub.featurePullRequest and when the default branch is
+	// protected
+	PullRequest bool
+}
+
+// FeatureFinish ...
+func FeatureFinish(repositoryPath string, gitHubToken string, options *FeatureOptions) {
+	// Open repository
+	repository, err := OpenRepository(repositoryPath, gitHubToken)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+	defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+
+	// Get current branch (feature branch)
+	featureBranchName, err := automation.GetCurrentBranch()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !strings.HasPrefix(featureBranchName, "feature/") {
+		log.Fatalf("You are on branch %q which is not a feature branch", featureBranchName)
+	}
+	fmt.Println("Finishing feature", featureBranchName)
+
+	strategy, err := FeatureStrategy(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Protected default branches only take pull requests
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, gitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	protected, err := IsBranchProtected(ctx, client, org, repo, defaultBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if protected || featureWithPullRequest(options) {
+		featureFinishPullRequest(ctx, client, org, repo, featureBranchName, defaultBranchName)
+		return
+	}
+
+	// Go to the default branch
+	out, err := automation.GoGitBranch(defaultBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Checking out", defaultBranchName, "branch")
+	fmt.Println(out)
+
+	// Pull and rebase
+	out, err = automation.PullAndRebase()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Pull and rebase", defaultBranchName, "branch")
+	fmt.Println(out)
+
+	// Get the feature branch into the default branch
+	switch strategy {
+	case FeatureMergeSquash:
+		subjects, err := automation.GetCommitSubjects(defaultBranchName, featureBranchName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		message := fmt.Sprintf("%s\n\n%s", PullRequestTitle(featureBranchName, nil), PullRequestBody(subjects))
+		out, err = automation.SquashBranch(featureBranchName, message)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Squashed %s into %s\n", featureBranchName, defaultBranchName)
+	case FeatureMergeRebase:
+		out, err = automation.RebaseBranch(featureBranchName, defaultBranchName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Rebased %s onto %s\n", featureBranchName, defaultBranchName)
+		fmt.Println(out)
+		_, err = automation.GoGitBranch(defaultBranchName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		out, err = automation.MergeFastForward(featureBranchName)
+		if err != nil {
+			log.Fatal(err)
+		}
+	default:
+		out, err = automation.MergeBranch(featureBranchName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Merged %s into %s\n", featureBranchName, defaultBranchName)
+	}
+	fmt.Println(out)
+
+	// Push
+	out, err = automation.GitPush()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Pushing", defaultBranchName, "branch")
+	fmt.Println(out)
+
+	// Delete remote and local feature branches, squashed branches are not
+	// merged for git
+	out, err = automation.DeleteRemoteBranch(featureBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Deleting remote branch", featureBranchName)
+	fmt.Println(out)
+
+	if strategy == FeatureMergeSquash {
+		out, err = automation.ForceDeleteLocalBranch(featureBranchName)
+	} else {
+		out, err = automation.DeleteLocalBranch(featureBranchName)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Deleting local branch", featureBranchName)
+	fmt.Println(out)
+}
+
+// featureFinishPullRequest pushes the feature branch and opens a pull
+// request from it into the default branch, unless there is one already
+func featureFinishPullRequest(ctx context.Context, client *github.Client, org string, repo string, featureBranchName string, defaultBranchName string) {
+	out, err := automation.PushLocalBranchToOrigin(featureBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(out)
+
+	pullRequest, err := FindOpenPullRequest(ctx, client, org, repo, featureBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if pullRequest != nil {
+		fmt.Printf("Pull request #%d already open %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+		return
+	}
+
+	subjects, err := automation.GetCommitSubjects("origin/"+defaultBranchName, featureBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	title := PullRequestTitle(featureBranchName, subjects)
+	body := PullRequestBody(subjects)
+	pullRequest, err = CreatePullRequest(ctx, client, org, repo, &github.NewPullRequest{
+		Title: &title,
+		Head:  &featureBranchName,
+		Base:  &defaultBranchName,
+		Body:  &body,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Opened pull request #%d %s\n", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+}
+
+// FeatureStrategy returns the merge strategy from the options or the
+// git-hub.featureMerge setting
+func FeatureStrategy(options *FeatureOptions) (string, error) {
+	strategy := options.Strategy
+	if strategy == "" {
+		configured, err := automation.GetConfig("featureMerge")
+		if err != nil {
+			return "", err
+		}
+		strategy = configured
+	}
+	switch strategy {
+	case "":
+		return FeatureMergeNoFF, nil
+	case FeatureMergeNoFF, FeatureMergeSquash, FeatureMergeRebase:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("Invalid feature merge strategy %q", strategy)
+}
+
+// featureWithPullRequest returns true if features go through a pull request
+func featureWithPullRequest(options *FeatureOptions) bool {
+	if options.PullRequest {
+		return true
+	}
+	featurePullRequest, err := automation.GetConfig("featurePullRequest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return featurePullRequest == "true"
+}
@@ -16,3 +16,86 @@
 // under the License.
 
 package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+// inGitRepository runs f from a new git repository with a git-hub setting,
+// an empty value leaves it unset
+func inGitRepository(t *testing.T, name string, value string, f func()) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+	err = os.Chdir(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exec.Command("git", "init", "-q").Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "" {
+		err = exec.Command("git", "config", "git-hub."+name, value).Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	f()
+}
+
+func TestFeatureStrategy(t *testing.T) {
+	tests := []struct {
+		strategy   string
+		configured string
+		expected   string
+	}{
+		{"", "", ghub.FeatureMergeNoFF},
+		{"", "squash", ghub.FeatureMergeSquash},
+		{"", "rebase", ghub.FeatureMergeRebase},
+		{"merge", "squash", ghub.FeatureMergeNoFF},
+		{"rebase", "", ghub.FeatureMergeRebase},
+		{"squash", "fast-forward", ghub.FeatureMergeSquash},
+	}
+	for _, test := range tests {
+		inGitRepository(t, "featureMerge", test.configured, func() {
+			strategy, err := ghub.FeatureStrategy(&ghub.FeatureOptions{Strategy: test.strategy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strategy != test.expected {
+				t.Fatalf("Expected strategy %q for %q configured as %q but got %q", test.expected, test.strategy, test.configured, strategy)
+			}
+		})
+	}
+}
+
+func TestFeatureStrategyInvalid(t *testing.T) {
+	tests := []struct {
+		strategy   string
+		configured string
+	}{
+		{"fast-forward", ""},
+		{"", "fast-forward"},
+	}
+	for _, test := range tests {
+		inGitRepository(t, "featureMerge", test.configured, func() {
+			_, err := ghub.FeatureStrategy(&ghub.FeatureOptions{Strategy: test.strategy})
+			if err == nil {
+				t.Fatalf("Expected an error for %q configured as %q", test.strategy, test.configured)
+			}
+		})
+	}
+}
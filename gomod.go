@@ -0,0 +1,194 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var moduleLineRegexp = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?[ \t]*$`)
+
+var majorSuffixRegexp = regexp.MustCompile(`/v[0-9]+$`)
+
+var moduleVersionRegexp = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+([-+].*)?$`)
+
+// IsGoModule returns true if there is a go.mod file at path
+func IsGoModule(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "go.mod"))
+	return err == nil
+}
+
+// GetModulePath returns the module path declared on the go.mod file at path
+func GetModulePath(path string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(path, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	match := moduleLineRegexp.FindSubmatch(data)
+	if match == nil {
+		return "", fmt.Errorf("ERROR no module directive found on %s", filepath.Join(path, "go.mod"))
+	}
+	return string(match[1]), nil
+}
+
+// MajorModulePath returns the module path for a major version, following
+// the Go modules rules, major versions 0 and 1 have no suffix.
+func MajorModulePath(modulePath string, major int) string {
+	basePath := majorSuffixRegexp.ReplaceAllString(modulePath, "")
+	if major < 2 {
+		return basePath
+	}
+	return fmt.Sprintf("%s/v%d", basePath, major)
+}
+
+// UpdateGoModuleMajorVersion rewrites the Go module at path so its module
+// path matches the given major version. It returns the list of changed files.
+func UpdateGoModuleMajorVersion(path string, major int) ([]string, error) {
+	if !IsGoModule(path) {
+		return nil, fmt.Errorf("ERROR no go.mod file found on %q", path)
+	}
+	modulePath, err := GetModulePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return RewriteGoModule(path, modulePath, MajorModulePath(modulePath, major))
+}
+
+// AddGoModuleRetract adds a retract directive for a module version, a
+// vX.Y.Z tag, to the go.mod file at path, the reason is added as a comment.
+func AddGoModuleRetract(path string, version string, reason string) error {
+	if !moduleVersionRegexp.MatchString(version) {
+		return fmt.Errorf("ERROR %q is not a Go module version", version)
+	}
+	goModFile := filepath.Join(path, "go.mod")
+	data, err := ioutil.ReadFile(goModFile)
+	if err != nil {
+		return err
+	}
+	retractVersion := version
+	retractRegexp := regexp.MustCompile(fmt.Sprintf(`(?m)^\s*(retract\s+)?%s\b`, regexp.QuoteMeta(retractVersion)))
+	if retractRegexp.Match(data) {
+		return nil
+	}
+	retract := fmt.Sprintf("retract %s", retractVersion)
+	if reason != "" {
+		retract = fmt.Sprintf("%s // %s", retract, strings.Replace(reason, "\n", " ", -1))
+	}
+	data = []byte(fmt.Sprintf("%s\n\n%s\n", strings.TrimRight(string(data), "\n"), retract))
+	return ioutil.WriteFile(goModFile, data, 0644)
+}
+
+// RewriteGoModule changes the module path on go.mod and rewrites all the
+// imports of the module packages on the .go files. It returns the list of
+// changed files.
+func RewriteGoModule(path string, oldModulePath string, newModulePath string) ([]string, error) {
+	changedFiles := []string{}
+	if oldModulePath == newModulePath {
+		return changedFiles, nil
+	}
+
+	// go.mod
+	goModFile := filepath.Join(path, "go.mod")
+	data, err := ioutil.ReadFile(goModFile)
+	if err != nil {
+		return nil, err
+	}
+	data = moduleLineRegexp.ReplaceAll(data, []byte("module "+newModulePath))
+	err = ioutil.WriteFile(goModFile, data, 0644)
+	if err != nil {
+		return nil, err
+	}
+	changedFiles = append(changedFiles, goModFile)
+
+	// .go files
+	err = filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if filePath != path && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || IsGoModule(filePath)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(filePath, ".go") {
+			return nil
+		}
+		changed, err := rewriteImports(filePath, oldModulePath, newModulePath)
+		if err != nil {
+			return err
+		}
+		if changed {
+			changedFiles = append(changedFiles, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changedFiles, nil
+}
+
+// rewriteImports rewrites the imports of a single .go file
+func rewriteImports(filePath string, oldModulePath string, newModulePath string) (bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, importSpec := range file.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			return false, err
+		}
+		if importPath != oldModulePath && !strings.HasPrefix(importPath, oldModulePath+"/") {
+			continue
+		}
+		importPath = newModulePath + strings.TrimPrefix(importPath, oldModulePath)
+		importSpec.Path.Value = strconv.Quote(importPath)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	var buf bytes.Buffer
+	err = format.Node(&buf, fset, file)
+	if err != nil {
+		return false, err
+	}
+	err = ioutil.WriteFile(filePath, buf.Bytes(), 0644)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
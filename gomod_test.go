@@ -0,0 +1,127 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestMajorModulePath(t *testing.T) {
+	tests := []struct {
+		modulePath string
+		major      int
+		expected   string
+	}{
+		{"github.com/repejota/git-hub", 1, "github.com/repejota/git-hub"},
+		{"github.com/repejota/git-hub", 2, "github.com/repejota/git-hub/v2"},
+		{"github.com/repejota/git-hub/v2", 3, "github.com/repejota/git-hub/v3"},
+	}
+	for _, test := range tests {
+		modulePath := ghub.MajorModulePath(test.modulePath, test.major)
+		if modulePath != test.expected {
+			t.Fatalf("Expected module path %q but got %q", test.expected, modulePath)
+		}
+	}
+}
+
+func TestUpdateGoModuleMajorVersion(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	goMod := "module github.com/org/repo\n\nrequire github.com/org/other v1.0.0\n"
+	err = ioutil.WriteFile(filepath.Join(path, "go.mod"), []byte(goMod), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goFile := "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/org/other\"\n\t\"github.com/org/repo/pkg\"\n)\n\nfunc main() { fmt.Println(pkg.Name, other.Name) }\n"
+	err = ioutil.WriteFile(filepath.Join(path, "main.go"), []byte(goFile), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changedFiles, err := ghub.UpdateGoModuleMajorVersion(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changedFiles) != 2 {
+		t.Fatalf("Expected 2 changed files but got %d", len(changedFiles))
+	}
+
+	modulePath, err := ghub.GetModulePath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modulePath != "github.com/org/repo/v2" {
+		t.Fatalf("Expected module path %q but got %q", "github.com/org/repo/v2", modulePath)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(path, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"github.com/org/repo/v2/pkg"`) {
+		t.Fatalf("Expected import to be rewritten but got %q", string(data))
+	}
+	if !strings.Contains(string(data), `"github.com/org/other"`) {
+		t.Fatalf("Expected foreign import to be kept but got %q", string(data))
+	}
+}
+
+func TestAddGoModuleRetract(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	err = ioutil.WriteFile(filepath.Join(path, "go.mod"), []byte("module github.com/org/repo\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = ghub.AddGoModuleRetract(path, "v1.2.3", "Broken build")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Tags without the v prefix are not module versions
+	err = ghub.AddGoModuleRetract(path, "1.2.4", "Broken build")
+	if err == nil {
+		t.Fatalf("Expected an error retracting a version without the v prefix")
+	}
+
+	expectedGoMod := "module github.com/org/repo\n\nretract v1.2.3 // Broken build\n"
+	data, err := ioutil.ReadFile(filepath.Join(path, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expectedGoMod {
+		t.Fatalf("Expected go.mod %q but got %q", expectedGoMod, string(data))
+	}
+}
@@ -20,45 +20,174 @@ package ghub
 import (
 	"context"
 	"fmt"
-	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/github"
-	"golang.org/x/oauth2"
 )
 
-// ListIssuesByRepo ...
-func ListIssuesByRepo(repoFullName string) ([]*github.Issue, error) {
+// Pull requests filters
+const (
+	PullRequestsInclude = "include"
+	PullRequestsExclude = "exclude"
+	PullRequestsOnly    = "only"
+)
+
+// IssueListOptions ...
+type IssueListOptions struct {
+	github.IssueListByRepoOptions
+	// Limit is the maximum number of issues to return, zero returns all
+	Limit int
+	// PullRequests includes, excludes or only returns pull requests
+	PullRequests string
+}
+
+// IssueSummary is the issue information shown on listings
+type IssueSummary struct {
+	Number    int       `json:"number" yaml:"number"`
+	Title     string    `json:"title" yaml:"title"`
+	State     string    `json:"state" yaml:"state"`
+	Labels    []string  `json:"labels" yaml:"labels"`
+	Assignees []string  `json:"assignees" yaml:"assignees"`
+	Author    string    `json:"author" yaml:"author"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+	URL       string    `json:"url" yaml:"url"`
+}
+
+// NewIssueSummary ...
+func NewIssueSummary(issue *github.Issue) *IssueSummary {
+	summary := &IssueSummary{
+		Number:    issue.GetNumber(),
+		Title:     issue.GetTitle(),
+		State:     issue.GetState(),
+		Labels:    []string{},
+		Assignees: []string{},
+		Author:    issue.GetUser().GetLogin(),
+		UpdatedAt: issue.GetUpdatedAt(),
+		URL:       issue.GetHTMLURL(),
+	}
+	for _, label := range issue.Labels {
+		summary.Labels = append(summary.Labels, label.GetName())
+	}
+	for _, assignee := range issue.Assignees {
+		summary.Assignees = append(summary.Assignees, assignee.GetLogin())
+	}
+	return summary
+}
+
+// ListIssuesByRepo returns the repository issues following all the result
+// pages up to the options limit.
+func ListIssuesByRepo(ctx context.Context, client *github.Client, repoFullName string, options *IssueListOptions) ([]*github.Issue, error) {
 	organization, repository := ParseRepositoryFullName(repoFullName)
-	ctx := context.Background()
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: githubToken,
-		},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
-	options := &github.IssueListByRepoOptions{}
-	issues, _, err := client.Issues.ListByRepo(ctx, organization, repository, options)
+
+	// Milestones are filtered by number
+	listOptions := options.IssueListByRepoOptions
+	if listOptions.Milestone != "" && listOptions.Milestone != "*" && listOptions.Milestone != "none" {
+		milestoneNumber, err := ResolveMilestoneNumber(ctx, client, organization, repository, listOptions.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		listOptions.Milestone = strconv.Itoa(milestoneNumber)
+	}
+
+	listOptions.PerPage = 100
+	if options.Limit > 0 && options.Limit < listOptions.PerPage {
+		listOptions.PerPage = options.Limit
+	}
+
+	result := []*github.Issue{}
+	for {
+		issues, response, err := client.Issues.ListByRepo(ctx, organization, repository, &listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if options.PullRequests == PullRequestsExclude && issue.IsPullRequest() {
+				continue
+			}
+			if options.PullRequests == PullRequestsOnly && !issue.IsPullRequest() {
+				continue
+			}
+			result = append(result, issue)
+			if options.Limit > 0 && len(result) == options.Limit {
+				return result, nil
+			}
+		}
+		if response.NextPage == 0 {
+			return result, nil
+		}
+		listOptions.Page = response.NextPage
+	}
+}
+
+// ListPickableIssues returns the open issues, not pull requests, assigned
+// to a user or unassigned, the most recently updated first.
+func ListPickableIssues(ctx context.Context, client *github.Client, repoFullName string, login string) ([]*github.Issue, error) {
+	result := []*github.Issue{}
+	for _, assignee := range []string{login, "none"} {
+		options := &IssueListOptions{
+			IssueListByRepoOptions: github.IssueListByRepoOptions{
+				State:    "open",
+				Assignee: assignee,
+			},
+			PullRequests: PullRequestsExclude,
+		}
+		issues, err := ListIssuesByRepo(ctx, client, repoFullName, options)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, issues...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].GetUpdatedAt().After(result[j].GetUpdatedAt())
+	})
+	return result, nil
+}
+
+// FormatIssueLine returns a one line description of an issue, with its
+// number, title, labels and age
+func FormatIssueLine(issue *github.Issue, now time.Time) string {
+	line := fmt.Sprintf("#%d %s", issue.GetNumber(), issue.GetTitle())
+	if len(issue.Labels) > 0 {
+		labels := []string{}
+		for _, label := range issue.Labels {
+			labels = append(labels, label.GetName())
+		}
+		line = fmt.Sprintf("%s [%s]", line, strings.Join(labels, ", "))
+	}
+	return fmt.Sprintf("%s %s", line, HumanizeTime(issue.GetCreatedAt(), now))
+}
+
+// NewIssueRequest returns the request to create an issue. Labels and
+// assignees are only set when there are some, as GitHub rejects null lists.
+func NewIssueRequest(title string, body string, labels []string, assignees []string) *github.IssueRequest {
+	issueRequest := &github.IssueRequest{
+		Title: &title,
+		Body:  &body,
+	}
+	if len(labels) > 0 {
+		issueRequest.Labels = &labels
+	}
+	if len(assignees) > 0 {
+		issueRequest.Assignees = &assignees
+	}
+	return issueRequest
+}
+
+// CreateIssue ...
+func CreateIssue(ctx context.Context, client *github.Client, organization string, repository string, issueRequest *github.IssueRequest) (*github.Issue, error) {
+	issue, _, err := client.Issues.Create(ctx, organization, repository, issueRequest)
 	if err != nil {
 		return nil, err
 	}
-	return issues, nil
+	return issue, nil
 }
 
 // GetIssue ...
-func GetIssue(organization string, repository string, issueID int) (*github.Issue, error) {
-	ctx := context.Background()
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: githubToken,
-		},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+func GetIssue(ctx context.Context, client *github.Client, organization string, repository string, issueID int) (*github.Issue, error) {
 	issue, _, err := client.Issues.Get(ctx, organization, repository, issueID)
 	if err != nil {
 		return nil, err
@@ -66,25 +195,310 @@ func GetIssue(organization string, repository string, issueID int) (*github.Issu
 	return issue, nil
 }
 
+// ListIssueComments returns all the comments of an issue
+func ListIssueComments(ctx context.Context, client *github.Client, organization string, repository string, number int) ([]*github.IssueComment, error) {
+	options := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	result := []*github.IssueComment{}
+	for {
+		comments, response, err := client.Issues.ListComments(ctx, organization, repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comments...)
+		if response.NextPage == 0 {
+			return result, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// ListIssueTimeline returns all the timeline events of an issue
+func ListIssueTimeline(ctx context.Context, client *github.Client, organization string, repository string, number int) ([]*github.Timeline, error) {
+	options := &github.ListOptions{PerPage: 100}
+	result := []*github.Timeline{}
+	for {
+		events, response, err := client.Issues.ListIssueTimeline(ctx, organization, repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events...)
+		if response.NextPage == 0 {
+			return result, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// ClosingCommitID returns the commit that closed an issue, or merged a pull
+// request, on its timeline events. It is empty when the issue was closed by
+// hand or reopened after being closed.
+func ClosingCommitID(events []*github.Timeline) string {
+	commitID := ""
+	for _, event := range events {
+		switch event.GetEvent() {
+		case "closed":
+			if event.GetCommitID() != "" {
+				commitID = event.GetCommitID()
+			}
+		case "merged":
+			commitID = event.GetCommitID()
+		case "reopened":
+			commitID = ""
+		}
+	}
+	return commitID
+}
+
+// crossReferenceEvent is an issue timeline event with the source issue of
+// cross references, that the github package does not decode
+type crossReferenceEvent struct {
+	Event  string `json:"event"`
+	Source struct {
+		Issue *github.Issue `json:"issue"`
+	} `json:"source"`
+}
+
+// ListLinkedPullRequests returns the pull requests of the repository that
+// reference an issue, from the cross references on the issue timeline
+func ListLinkedPullRequests(ctx context.Context, client *github.Client, organization string, repository string, number int) ([]*github.Issue, error) {
+	repositoryURL := fmt.Sprintf("repos/%s/%s", organization, repository)
+	pullRequests := []*github.Issue{}
+	seen := map[int]bool{}
+	page := 1
+	for {
+		url := fmt.Sprintf("%s/issues/%d/timeline?per_page=100&page=%d", repositoryURL, number, page)
+		request, err := client.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Accept", "application/vnd.github.mockingbird-preview")
+		events := []*crossReferenceEvent{}
+		response, err := client.Do(ctx, request, &events)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			pullRequest := event.Source.Issue
+			if event.Event != "cross-referenced" || pullRequest == nil || !pullRequest.IsPullRequest() {
+				continue
+			}
+			if !strings.HasSuffix(strings.ToLower(pullRequest.GetRepositoryURL()), strings.ToLower("/"+repositoryURL)) || seen[pullRequest.GetNumber()] {
+				continue
+			}
+			seen[pullRequest.GetNumber()] = true
+			pullRequests = append(pullRequests, pullRequest)
+		}
+		if response.NextPage == 0 {
+			return pullRequests, nil
+		}
+		page = response.NextPage
+	}
+}
+
+// DescribeTimelineEvent returns a one line description of an issue
+// timeline event, or an empty string for comments and unsupported events
+func DescribeTimelineEvent(event *github.Timeline) string {
+	actor := event.GetActor().GetLogin()
+	switch event.GetEvent() {
+	case "labeled":
+		return fmt.Sprintf("@%s added the %s label", actor, event.GetLabel().GetName())
+	case "unlabeled":
+		return fmt.Sprintf("@%s removed the %s label", actor, event.GetLabel().GetName())
+	case "assigned":
+		return fmt.Sprintf("@%s assigned @%s", actor, event.GetAssignee().GetLogin())
+	case "unassigned":
+		return fmt.Sprintf("@%s unassigned @%s", actor, event.GetAssignee().GetLogin())
+	case "milestoned":
+		return fmt.Sprintf("@%s added this to the %s milestone", actor, event.GetMilestone().GetTitle())
+	case "demilestoned":
+		return fmt.Sprintf("@%s removed this from the %s milestone", actor, event.GetMilestone().GetTitle())
+	case "renamed":
+		return fmt.Sprintf("@%s changed the title from %q to %q", actor, event.GetRename().GetFrom(), event.GetRename().GetTo())
+	case "closed":
+		return fmt.Sprintf("@%s closed this", actor)
+	case "reopened":
+		return fmt.Sprintf("@%s reopened this", actor)
+	case "merged":
+		return fmt.Sprintf("@%s merged this", actor)
+	case "referenced":
+		return fmt.Sprintf("@%s referenced this from commit %.7s", actor, event.GetCommitID())
+	case "cross-referenced":
+		return fmt.Sprintf("@%s mentioned this from another issue", event.GetSource().GetActor().GetLogin())
+	}
+	return ""
+}
+
 // AssignUserToIssue ...
-func AssignUserToIssue(organization string, repository string, user *github.User, issue *github.Issue) error {
-	ctx := context.Background()
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: githubToken,
-		},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+func AssignUserToIssue(ctx context.Context, client *github.Client, organization string, repository string, user *github.User, issue *github.Issue) error {
 	users := []string{*user.Login}
-	issue, _, err := client.Issues.AddAssignees(ctx, organization, repository, *issue.Number, users)
+	_, _, err := client.Issues.AddAssignees(ctx, organization, repository, *issue.Number, users)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close reasons
+const (
+	CloseReasonCompleted  = "completed"
+	CloseReasonNotPlanned = "not_planned"
+)
+
+// EditIssue ...
+func EditIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, issueRequest *github.IssueRequest) (*github.Issue, error) {
+	issue, _, err := client.Issues.Edit(ctx, organization, repository, number, issueRequest)
+	if err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// CloseIssue closes an issue with a reason, completed or not_planned, an
+// empty reason lets GitHub choose it
+func CloseIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, reason string) error {
+	body := map[string]string{
+		"state": "closed",
+	}
+	if reason != "" {
+		body["state_reason"] = reason
+	}
+	url := fmt.Sprintf("repos/%s/%s/issues/%d", organization, repository, number)
+	request, err := client.NewRequest("PATCH", url, body)
 	if err != nil {
 		return err
 	}
+	_, err = client.Do(ctx, request, nil)
+	return err
+}
+
+// ReopenIssue ...
+func ReopenIssue(ctx context.Context, client *github.Client, organization string, repository string, number int) error {
+	state := "open"
+	_, err := EditIssue(ctx, client, organization, repository, number, &github.IssueRequest{
+		State: &state,
+	})
+	return err
+}
+
+// RemoveLabelsFromIssue ...
+func RemoveLabelsFromIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, labels []string) error {
+	for _, label := range labels {
+		_, err := client.Issues.RemoveLabelForIssue(ctx, organization, repository, number, label)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// AddAssigneesToIssue ...
+func AddAssigneesToIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, assignees []string) error {
+	_, _, err := client.Issues.AddAssignees(ctx, organization, repository, number, assignees)
+	return err
+}
+
+// RemoveAssigneesFromIssue ...
+func RemoveAssigneesFromIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, assignees []string) error {
+	_, _, err := client.Issues.RemoveAssignees(ctx, organization, repository, number, assignees)
+	return err
+}
+
+// SearchIssueNumbers returns the numbers of the repository issues matching
+// a search query, like "is:open label:bug"
+func SearchIssueNumbers(ctx context.Context, client *github.Client, organization string, repository string, query string) ([]int, error) {
+	options := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	query = fmt.Sprintf("repo:%s/%s %s", organization, repository, query)
+	numbers := []int{}
+	for {
+		result, response, err := client.Search.Issues(ctx, query, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range result.Issues {
+			numbers = append(numbers, issue.GetNumber())
+		}
+		if response.NextPage == 0 {
+			return numbers, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// IssueBranch is the issue information encoded on an issue branch name,
+// issue/<number>-<slug> or issue/<repository slug>-<number>-<slug> for
+// issues of another repository
+type IssueBranch struct {
+	Number         int
+	RepositorySlug string
+	Slug           string
+}
+
+var (
+	issueBranchNameRegexp           = regexp.MustCompile(`^issue/([0-9]+)(?:-(.*))?$`)
+	repositoryIssueBranchNameRegexp = regexp.MustCompile(`^issue/(.+?)-([0-9]+)(?:-(.*))?$`)
+)
+
+// ParseIssueBranch parses an issue branch name
+func ParseIssueBranch(branchName string) (*IssueBranch, error) {
+	issueBranch := &IssueBranch{}
+	numberPart := ""
+	if match := issueBranchNameRegexp.FindStringSubmatch(branchName); match != nil {
+		numberPart = match[1]
+		issueBranch.Slug = match[2]
+	} else if match := repositoryIssueBranchNameRegexp.FindStringSubmatch(branchName); match != nil {
+		issueBranch.RepositorySlug = match[1]
+		numberPart = match[2]
+		issueBranch.Slug = match[3]
+	} else {
+		return nil, fmt.Errorf("Branch %q is not an issue branch", branchName)
+	}
+	number, err := strconv.Atoi(numberPart)
+	if err != nil {
+		return nil, err
+	}
+	issueBranch.Number = number
+	return issueBranch, nil
+}
+
+// IssueBranchName returns the branch name for an issue, repositoryFullName
+// is only given for issues of another repository
+func IssueBranchName(issue *github.Issue, repositoryFullName string) string {
+	if repositoryFullName != "" {
+		return fmt.Sprintf("issue/%s-%s", SlugifyRepository(repositoryFullName), SlugifyIssue(issue))
+	}
+	return fmt.Sprintf("issue/%s", SlugifyIssue(issue))
+}
+
+// FindIssueBranch returns the first branch name that belongs to an issue,
+// regardless of the title slug, or an empty string if there is none.
+// repositorySlug is only given for issues of another repository.
+func FindIssueBranch(branchNames []string, number int, repositorySlug string) string {
+	for _, branchName := range branchNames {
+		issueBranch, err := ParseIssueBranch(branchName)
+		if err != nil {
+			continue
+		}
+		if issueBranch.Number == number && issueBranch.RepositorySlug == repositorySlug {
+			return branchName
+		}
+	}
+	return ""
+}
+
+// IsAssignedTo returns true if a user is one of the assignees of an issue
+func IsAssignedTo(issue *github.Issue, login string) bool {
+	for _, assignee := range issue.Assignees {
+		if assignee.GetLogin() == login {
+			return true
+		}
+	}
+	return false
+}
+
 // SlugifyIssue ...
 func SlugifyIssue(issue *github.Issue) string {
 	var re = regexp.MustCompile("[^a-z0-9]+")
@@ -92,3 +506,66 @@ func SlugifyIssue(issue *github.Issue) string {
 	slugIssue := fmt.Sprintf("%d-%s", issue.GetNumber(), slugifyTitle)
 	return slugIssue
 }
+
+// issueBranchRegexp matches issue branch names on merge commit messages
+var issueBranchRegexp = regexp.MustCompile(`issue/([0-9]+)-`)
+
+// ListIssuesClosedSince returns the issues and pull requests closed after a
+// date.
+func ListIssuesClosedSince(ctx context.Context, client *github.Client, organization string, repository string, since time.Time) ([]*github.Issue, error) {
+	options := &github.IssueListByRepoOptions{
+		State: "closed",
+		Since: since,
+	}
+	closedIssues := []*github.Issue{}
+	for {
+		issues, response, err := client.Issues.ListByRepo(ctx, organization, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.GetClosedAt().After(since) {
+				closedIssues = append(closedIssues, issue)
+			}
+		}
+		if response.NextPage == 0 {
+			return closedIssues, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// ParseMergedIssueNumbers returns the issue numbers of the issue branches
+// referenced on merge commit messages.
+func ParseMergedIssueNumbers(messages []string) []int {
+	numbers := []int{}
+	seen := map[int]bool{}
+	for _, message := range messages {
+		for _, match := range issueBranchRegexp.FindAllStringSubmatch(message, -1) {
+			number, err := strconv.Atoi(match[1])
+			if err != nil || seen[number] {
+				continue
+			}
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// CommentIssue ...
+func CommentIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, body string) (*github.IssueComment, error) {
+	comment, _, err := client.Issues.CreateComment(ctx, organization, repository, number, &github.IssueComment{
+		Body: &body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// AddLabelsToIssue ...
+func AddLabelsToIssue(ctx context.Context, client *github.Client, organization string, repository string, number int, labels []string) error {
+	_, _, err := client.Issues.AddLabelsToIssue(ctx, organization, repository, number, labels)
+	return err
+}
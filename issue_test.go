@@ -16,3 +16,168 @@
 // under the License.
 
 package ghub_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub"
+)
+
+func TestParseMergedIssueNumbers(t *testing.T) {
+	messages := []string{
+		"Merge branch 'issue/12-fix-the-thing'",
+		"Merge pull request #15 from repejota/issue/13-another-fix\n\nAnother fix",
+		"Merge branch 'issue/12-fix-the-thing'",
+		"Merge branch 'release/1.2.3'",
+	}
+	expectedNumbers := []int{12, 13}
+
+	numbers := ghub.ParseMergedIssueNumbers(messages)
+	if !reflect.DeepEqual(numbers, expectedNumbers) {
+		t.Fatalf("Expected issue numbers %v but got %v", expectedNumbers, numbers)
+	}
+}
+
+func TestDescribeTimelineEvent(t *testing.T) {
+	login := "repejota"
+	labeled := "labeled"
+	labelName := "bug"
+	commented := "commented"
+	tests := []struct {
+		event    *github.Timeline
+		expected string
+	}{
+		{&github.Timeline{Event: &labeled, Actor: &github.User{Login: &login}, Label: &github.Label{Name: &labelName}}, "@repejota added the bug label"},
+		{&github.Timeline{Event: &commented, Actor: &github.User{Login: &login}}, ""},
+	}
+	for _, test := range tests {
+		description := ghub.DescribeTimelineEvent(test.event)
+		if description != test.expected {
+			t.Fatalf("Expected description %q but got %q", test.expected, description)
+		}
+	}
+}
+
+func TestClosingCommitID(t *testing.T) {
+	closed := "closed"
+	merged := "merged"
+	reopened := "reopened"
+	commented := "commented"
+	fix := "1111111111111111111111111111111111111111"
+	merge := "2222222222222222222222222222222222222222"
+	tests := []struct {
+		name     string
+		events   []*github.Timeline
+		expected string
+	}{
+		{"closed by a commit", []*github.Timeline{{Event: &commented}, {Event: &closed, CommitID: &fix}}, fix},
+		{"closed but not fixed", []*github.Timeline{{Event: &commented}, {Event: &closed}}, ""},
+		{"merged pull request", []*github.Timeline{{Event: &merged, CommitID: &merge}, {Event: &closed}}, merge},
+		{"reopened", []*github.Timeline{{Event: &closed, CommitID: &fix}, {Event: &reopened}}, ""},
+		{"reopened and closed by hand", []*github.Timeline{{Event: &closed, CommitID: &fix}, {Event: &reopened}, {Event: &closed}}, ""},
+		{"reopened and fixed", []*github.Timeline{{Event: &closed}, {Event: &reopened}, {Event: &closed, CommitID: &fix}}, fix},
+	}
+	for _, test := range tests {
+		commitID := ghub.ClosingCommitID(test.events)
+		if commitID != test.expected {
+			t.Fatalf("Expected closing commit %q for %s but got %q", test.expected, test.name, commitID)
+		}
+	}
+}
+
+func TestParseIssueBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *ghub.IssueBranch
+	}{
+		{"issue/12-fix-the-thing", &ghub.IssueBranch{Number: 12, Slug: "fix-the-thing"}},
+		{"issue/12-fix-3-things", &ghub.IssueBranch{Number: 12, Slug: "fix-3-things"}},
+		{"issue/12", &ghub.IssueBranch{Number: 12}},
+		{"issue/repejota-git-hub-7-add-docs", &ghub.IssueBranch{Number: 7, RepositorySlug: "repejota-git-hub", Slug: "add-docs"}},
+	}
+	for _, test := range tests {
+		issueBranch, err := ghub.ParseIssueBranch(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(issueBranch, test.expected) {
+			t.Fatalf("Expected issue branch %+v but got %+v", test.expected, issueBranch)
+		}
+	}
+
+	_, err := ghub.ParseIssueBranch("release/1.2.3")
+	if err == nil {
+		t.Fatal("Expected an error parsing a release branch")
+	}
+}
+
+func TestFindIssueBranch(t *testing.T) {
+	branchNames := []string{
+		"master",
+		"issue/120-another-issue",
+		"issue/repejota-git-hub-12-old-title",
+		"issue/12-old-title",
+	}
+	tests := []struct {
+		number         int
+		repositorySlug string
+		expected       string
+	}{
+		{12, "", "issue/12-old-title"},
+		{12, "repejota-git-hub", "issue/repejota-git-hub-12-old-title"},
+		{120, "", "issue/120-another-issue"},
+		{1, "", ""},
+	}
+	for _, test := range tests {
+		branchName := ghub.FindIssueBranch(branchNames, test.number, test.repositorySlug)
+		if branchName != test.expected {
+			t.Fatalf("Expected branch %q but got %q", test.expected, branchName)
+		}
+	}
+}
+
+func TestFormatIssueLine(t *testing.T) {
+	number := 12
+	title := "Fix the thing"
+	bug := "bug"
+	ui := "ui"
+	now := time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC)
+	createdAt := now.Add(-3 * 24 * time.Hour)
+	issue := &github.Issue{
+		Number:    &number,
+		Title:     &title,
+		Labels:    []github.Label{{Name: &bug}, {Name: &ui}},
+		CreatedAt: &createdAt,
+	}
+	expected := "#12 Fix the thing [bug, ui] 3 days ago"
+
+	line := ghub.FormatIssueLine(issue, now)
+	if line != expected {
+		t.Fatalf("Expected line %q but got %q", expected, line)
+	}
+}
+
+func TestNewIssueRequest(t *testing.T) {
+	tests := []struct {
+		labels    []string
+		assignees []string
+		expected  string
+	}{
+		{nil, nil, `{"title":"Title","body":"Body"}`},
+		{[]string{}, []string{}, `{"title":"Title","body":"Body"}`},
+		{[]string{"bug"}, []string{"repejota"}, `{"title":"Title","body":"Body","labels":["bug"],"assignees":["repejota"]}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(ghub.NewIssueRequest("Title", "Body", test.labels, test.assignees))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Fatalf("Expected request %s but got %s", test.expected, data)
+		}
+	}
+}
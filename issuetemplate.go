@@ -0,0 +1,135 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IssueTemplate is an issue template from .github/ISSUE_TEMPLATE
+type IssueTemplate struct {
+	Name      string
+	About     string
+	Title     string
+	Labels    []string
+	Assignees []string
+	Body      string
+}
+
+// issueTemplateFrontMatter is the YAML front matter of an issue template,
+// labels and assignees can be a list or a comma separated string
+type issueTemplateFrontMatter struct {
+	Name      string      `yaml:"name"`
+	About     string      `yaml:"about"`
+	Title     string      `yaml:"title"`
+	Labels    interface{} `yaml:"labels"`
+	Assignees interface{} `yaml:"assignees"`
+}
+
+// ParseIssueTemplate parses an issue template with an optional YAML front
+// matter
+func ParseIssueTemplate(data []byte) (*IssueTemplate, error) {
+	text := strings.Replace(string(data), "\r\n", "\n", -1)
+	template := &IssueTemplate{
+		Body: text,
+	}
+	if !strings.HasPrefix(text, "---\n") {
+		return template, nil
+	}
+	end := strings.Index(text[4:], "\n---")
+	if end == -1 {
+		return nil, fmt.Errorf("ERROR unterminated issue template front matter")
+	}
+	frontMatter := &issueTemplateFrontMatter{}
+	err := yaml.Unmarshal([]byte(text[4:4+end]), frontMatter)
+	if err != nil {
+		return nil, err
+	}
+	template.Name = frontMatter.Name
+	template.About = frontMatter.About
+	template.Title = frontMatter.Title
+	template.Labels = parseList(frontMatter.Labels)
+	template.Assignees = parseList(frontMatter.Assignees)
+	body := text[4+end+len("\n---"):]
+	template.Body = strings.TrimLeft(strings.TrimPrefix(body, "\n"), "\n")
+	return template, nil
+}
+
+// LoadIssueTemplates returns the markdown issue templates of the repository
+// at path sorted by file name
+func LoadIssueTemplates(path string) ([]*IssueTemplate, error) {
+	files, err := filepath.Glob(filepath.Join(path, ".github", "ISSUE_TEMPLATE", "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	templates := []*IssueTemplate{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		template, err := ParseIssueTemplate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		if template.Name == "" {
+			template.Name = strings.TrimSuffix(filepath.Base(file), ".md")
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// ParseIssueText splits an edited issue text into its title, the first
+// line, and its body, the rest of lines.
+func ParseIssueText(text string) (string, string) {
+	text = strings.TrimLeft(text, "\n")
+	parts := strings.SplitN(text, "\n", 2)
+	title := strings.TrimSpace(parts[0])
+	body := ""
+	if len(parts) > 1 {
+		body = strings.TrimSpace(parts[1])
+	}
+	return title, body
+}
+
+// parseList returns the items of a YAML list or a comma separated string
+func parseList(value interface{}) []string {
+	list := []string{}
+	switch value := value.(type) {
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				list = append(list, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			list = append(list, strings.TrimSpace(fmt.Sprint(item)))
+		}
+	}
+	return list
+}
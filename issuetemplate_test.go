@@ -0,0 +1,96 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestParseIssueTemplate(t *testing.T) {
+	data := "---\nname: Bug report\nabout: Create a report\ntitle: '[BUG] '\nlabels: bug, triage\nassignees:\n  - repejota\n---\n\n**Describe the bug**\n"
+
+	template, err := ghub.ParseIssueTemplate([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ghub.IssueTemplate{
+		Name:      "Bug report",
+		About:     "Create a report",
+		Title:     "[BUG] ",
+		Labels:    []string{"bug", "triage"},
+		Assignees: []string{"repejota"},
+		Body:      "**Describe the bug**\n",
+	}
+	if !reflect.DeepEqual(template, expected) {
+		t.Fatalf("Expected template %+v but got %+v", expected, template)
+	}
+}
+
+func TestParseIssueTemplateWithoutFrontMatter(t *testing.T) {
+	data := "**Describe the bug**\n"
+
+	template, err := ghub.ParseIssueTemplate([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.Body != data {
+		t.Fatalf("Expected body %q but got %q", data, template.Body)
+	}
+}
+
+func TestLoadIssueTemplates(t *testing.T) {
+	path, err := ioutil.TempDir("", "ghub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	templatesPath := filepath.Join(path, ".github", "ISSUE_TEMPLATE")
+	err = os.MkdirAll(templatesPath, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(templatesPath, "feature.md"), []byte("Feature\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := ghub.LoadIssueTemplates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].Name != "feature" {
+		t.Fatalf("Expected a feature template but got %+v", templates)
+	}
+}
+
+func TestParseIssueText(t *testing.T) {
+	title, body := ghub.ParseIssueText("\nThe title\n\nThe body\nwith lines\n")
+	if title != "The title" {
+		t.Fatalf("Expected title %q but got %q", "The title", title)
+	}
+	if body != "The body\nwith lines" {
+		t.Fatalf("Expected body %q but got %q", "The body\nwith lines", body)
+	}
+}
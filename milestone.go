@@ -0,0 +1,85 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/github"
+)
+
+// GetMilestoneByTitle returns the milestone with a title, open or closed, or
+// nil if there is none.
+func GetMilestoneByTitle(ctx context.Context, client *github.Client, organization string, repository string, title string) (*github.Milestone, error) {
+	options := &github.MilestoneListOptions{
+		State: "all",
+	}
+	for {
+		milestones, response, err := client.Issues.ListMilestones(ctx, organization, repository, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, milestone := range milestones {
+			if milestone.GetTitle() == title {
+				return milestone, nil
+			}
+		}
+		if response.NextPage == 0 {
+			return nil, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// ResolveMilestoneNumber returns the number of a milestone given by its
+// title or its number
+func ResolveMilestoneNumber(ctx context.Context, client *github.Client, organization string, repository string, milestone string) (int, error) {
+	if number, err := strconv.Atoi(milestone); err == nil {
+		return number, nil
+	}
+	found, err := GetMilestoneByTitle(ctx, client, organization, repository, milestone)
+	if err != nil {
+		return 0, err
+	}
+	if found == nil {
+		return 0, fmt.Errorf("There is no milestone %q", milestone)
+	}
+	return found.GetNumber(), nil
+}
+
+// CloseMilestone ...
+func CloseMilestone(ctx context.Context, client *github.Client, organization string, repository string, milestone *github.Milestone) error {
+	state := "closed"
+	_, _, err := client.Issues.EditMilestone(ctx, organization, repository, milestone.GetNumber(), &github.Milestone{
+		State: &state,
+	})
+	return err
+}
+
+// CreateMilestone ...
+func CreateMilestone(ctx context.Context, client *github.Client, organization string, repository string, title string) (*github.Milestone, error) {
+	milestone, _, err := client.Issues.CreateMilestone(ctx, organization, repository, &github.Milestone{
+		Title: &title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
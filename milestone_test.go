@@ -0,0 +1,36 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestResolveMilestoneNumber(t *testing.T) {
+	// A milestone number resolves without asking GitHub
+	number, err := ghub.ResolveMilestoneNumber(context.Background(), nil, "repejota", "git-hub", "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number != 7 {
+		t.Fatalf("Expected milestone number 7 but got %d", number)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ErrCanceled is returned by Find when nothing is chosen
+var ErrCanceled = errors.New("Canceled")
+
+// Terminal control sequences used by the finder
+const (
+	alternateScreen = "\x1b[?1049h"
+	normalScreen    = "\x1b[?1049l"
+	clearScreen     = "\x1b[2J\x1b[H"
+)
+
+// IsInteractive returns true if both stdin and stdout are a terminal, so
+// the finder can be used
+func IsInteractive() bool {
+	return IsTerminal() && terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// FuzzyMatch returns true if all the characters of the pattern appear on
+// the text in the same order, ignoring case
+func FuzzyMatch(pattern string, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(pattern) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// FuzzyFilter returns the indexes of the items matching a pattern
+func FuzzyFilter(pattern string, items []string) []int {
+	matches := []int{}
+	for i, item := range items {
+		if FuzzyMatch(pattern, item) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// Find opens a fuzzy finder over the items on the terminal and returns the
+// index of the chosen item. The list is filtered as the user types, moved
+// with the arrow keys or ctrl-p and ctrl-n, and preview, if not nil, gives
+// the text shown below the list for the selected item. ErrCanceled is
+// returned on escape or ctrl-c.
+func Find(prompt string, items []string, preview func(int) string) (int, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return 0, err
+	}
+	defer terminal.Restore(fd, state)
+	fmt.Print(alternateScreen)
+	defer fmt.Print(normalScreen)
+
+	query := []rune{}
+	selected := 0
+	matches := FuzzyFilter("", items)
+	buf := make([]byte, 64)
+	for {
+		drawFinder(prompt, string(query), items, matches, selected, preview)
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		input := buf[:n]
+		switch {
+		case string(input) == "\x1b" || input[0] == 3:
+			return 0, ErrCanceled
+		case input[0] == '\r' || input[0] == '\n':
+			if len(matches) == 0 {
+				continue
+			}
+			return matches[selected], nil
+		case string(input) == "\x1b[A" || string(input) == "\x1bOA" || input[0] == 16:
+			if selected > 0 {
+				selected--
+			}
+		case string(input) == "\x1b[B" || string(input) == "\x1bOB" || input[0] == 14:
+			if selected < len(matches)-1 {
+				selected++
+			}
+		case input[0] == 127 || input[0] == 8:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+			}
+		case input[0] == 21:
+			query = query[:0]
+		case input[0] == 27:
+			// Ignore other escape sequences
+			continue
+		default:
+			for _, r := range string(input) {
+				if unicode.IsPrint(r) {
+					query = append(query, r)
+				}
+			}
+		}
+		matches = FuzzyFilter(string(query), items)
+		if selected >= len(matches) {
+			selected = len(matches) - 1
+		}
+		if selected < 0 {
+			selected = 0
+		}
+	}
+}
+
+// drawFinder draws the finder prompt, the list of matching items around the
+// selected one and the preview of the selected item
+func drawFinder(prompt string, query string, items []string, matches []int, selected int, preview func(int) string) {
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+	listHeight := height - 2
+	if preview != nil {
+		listHeight = height / 2
+	}
+	if listHeight < 1 {
+		listHeight = 1
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %s", color.CyanString(prompt), query),
+		color.New(color.Faint).Sprintf("  %d/%d", len(matches), len(items)),
+	}
+	first := 0
+	if selected >= listHeight {
+		first = selected - listHeight + 1
+	}
+	for i := first; i < len(matches) && i < first+listHeight; i++ {
+		line := truncate(items[matches[i]], width-2)
+		if i == selected {
+			lines = append(lines, color.New(color.Bold).Sprintf("> %s", line))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+	if preview != nil && len(matches) > 0 {
+		lines = append(lines, strings.Repeat("─", width))
+		for _, line := range strings.Split(preview(matches[selected]), "\n") {
+			if len(lines) >= height {
+				break
+			}
+			lines = append(lines, truncate(strings.TrimRight(line, "\r"), width))
+		}
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	fmt.Print(clearScreen + strings.Join(lines, "\r\n"))
+}
+
+// truncate cuts a line to a number of characters
+func truncate(line string, width int) string {
+	if width < 1 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	return string([]rune(line)[:width])
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/repejota/git-hub/output"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		text     string
+		expected bool
+	}{
+		{"", "#12 Fix the thing", true},
+		{"fix", "#12 Fix the thing", true},
+		{"12 thg", "#12 Fix the thing", true},
+		{"thing fix", "#12 Fix the thing", false},
+		{"bug", "#12 Fix the thing", false},
+	}
+	for _, test := range tests {
+		matches := output.FuzzyMatch(test.pattern, test.text)
+		if matches != test.expected {
+			t.Fatalf("Expected %q matching %q to be %v", test.pattern, test.text, test.expected)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	items := []string{"#1 Add docs", "#2 Fix build", "#3 Fix docs"}
+	expected := []int{0, 2}
+
+	matches := output.FuzzyFilter("docs", items)
+	if !reflect.DeepEqual(matches, expected) {
+		t.Fatalf("Expected matches %v but got %v", expected, matches)
+	}
+}
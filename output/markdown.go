@@ -0,0 +1,109 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+var (
+	headingRegexp    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	taskRegexp       = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	bulletRegexp     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	numberedRegexp   = regexp.MustCompile(`^(\s*)([0-9]+)[.)]\s+(.*)$`)
+	quoteRegexp      = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ruleRegexp       = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	inlineCodeRegexp = regexp.MustCompile("`([^`]+)`")
+	boldRegexp       = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	linkRegexp       = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+)
+
+// RenderMarkdown renders Markdown text for the terminal, with styled
+// headings, lists, task lists with checkboxes, quotes and code blocks.
+func RenderMarkdown(text string) string {
+	heading := color.New(color.Bold, color.FgCyan)
+	code := color.New(color.FgYellow)
+	quote := color.New(color.Faint)
+
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	rendered := []string{}
+	inCodeBlock := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			rendered = append(rendered, "    "+code.Sprint(line))
+			continue
+		}
+
+		if match := headingRegexp.FindStringSubmatch(line); match != nil {
+			title := renderInline(match[2])
+			if len(match[1]) == 1 {
+				title = strings.ToUpper(title)
+			}
+			rendered = append(rendered, heading.Sprint(title))
+			continue
+		}
+		if ruleRegexp.MatchString(line) {
+			rendered = append(rendered, strings.Repeat("─", 40))
+			continue
+		}
+		if match := taskRegexp.FindStringSubmatch(line); match != nil {
+			checkbox := "☐"
+			if match[2] != " " {
+				checkbox = "☑"
+			}
+			rendered = append(rendered, fmt.Sprintf("%s%s %s", match[1], checkbox, renderInline(match[3])))
+			continue
+		}
+		if match := bulletRegexp.FindStringSubmatch(line); match != nil {
+			rendered = append(rendered, fmt.Sprintf("%s• %s", match[1], renderInline(match[2])))
+			continue
+		}
+		if match := numberedRegexp.FindStringSubmatch(line); match != nil {
+			rendered = append(rendered, fmt.Sprintf("%s%s. %s", match[1], match[2], renderInline(match[3])))
+			continue
+		}
+		if match := quoteRegexp.FindStringSubmatch(line); match != nil {
+			rendered = append(rendered, quote.Sprint("│ "+renderInline(match[1])))
+			continue
+		}
+		rendered = append(rendered, renderInline(line))
+	}
+	return strings.TrimRight(strings.Join(rendered, "\n"), "\n")
+}
+
+// renderInline renders inline code, bold text and links
+func renderInline(text string) string {
+	code := color.New(color.FgYellow)
+	bold := color.New(color.Bold)
+	text = inlineCodeRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		return code.Sprint(strings.Trim(match, "`"))
+	})
+	text = boldRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		return bold.Sprint(match[2 : len(match)-2])
+	})
+	text = linkRegexp.ReplaceAllString(text, "$1 ($2)")
+	return text
+}
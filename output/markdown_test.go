@@ -0,0 +1,37 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/repejota/git-hub/output"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	color.NoColor = true
+
+	markdown := "# Title\n\n## Steps\n\n- [ ] Todo\n- [x] Done\n* Item with `code`\n1. First\n> Quote\n\n```go\nfmt.Println()\n```\nSee [docs](https://example.com) **now**"
+	expected := "TITLE\n\nSteps\n\n☐ Todo\n☑ Done\n• Item with code\n1. First\n│ Quote\n\n    fmt.Println()\nSee docs (https://example.com) now"
+
+	rendered := output.RenderMarkdown(markdown)
+	if rendered != expected {
+		t.Fatalf("Expected rendered markdown %q but got %q", expected, rendered)
+	}
+}
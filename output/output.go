@@ -0,0 +1,285 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"gopkg.in/yaml.v2"
+)
+
+// Formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatCSV   = "csv"
+)
+
+// Options ...
+type Options struct {
+	// Format is one of table, json, yaml or csv
+	Format string
+	// Template is a Go template rendered for each item, it takes
+	// precedence over Format
+	Template string
+	// TTY renders aligned and colored tables, otherwise tables are plain
+	// tab separated values without header
+	TTY bool
+}
+
+// IsTerminal returns true if stdout is a terminal
+func IsTerminal() bool {
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// Render writes data, a struct or a slice of structs, using the options
+// format. Struct fields are named after their json tag.
+func Render(w io.Writer, options *Options, data interface{}) error {
+	if options.Template != "" {
+		return renderTemplate(w, options.Template, data)
+	}
+	switch options.Format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	case FormatYAML:
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case FormatCSV:
+		return renderCSV(w, data)
+	case FormatTable, "":
+		return renderTable(w, options.TTY, data)
+	}
+	return fmt.Errorf("Invalid output format %q", options.Format)
+}
+
+// renderTemplate executes a template for each item
+func renderTemplate(w io.Writer, text string, data interface{}) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return err
+	}
+	for _, item := range items(data) {
+		err = tmpl.Execute(w, item.Interface())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderCSV writes a header with the field names and a record per item
+func renderCSV(w io.Writer, data interface{}) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(fieldNames(data))
+	if err != nil {
+		return err
+	}
+	for _, item := range items(data) {
+		err = writer.Write(fieldValues(item))
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Highlighter is implemented by items that can ask to be highlighted on
+// terminal tables
+type Highlighter interface {
+	Highlighted() bool
+}
+
+// renderTable writes a row per item of a slice or a row per field of a
+// single struct
+func renderTable(w io.Writer, tty bool, data interface{}) error {
+	names := fieldNames(data)
+	rows := [][]string{}
+	highlighted := map[int]bool{}
+	if isSlice(data) {
+		if tty {
+			header := make([]string, len(names))
+			for i, name := range names {
+				header[i] = strings.ToUpper(strings.Replace(name, "_", " ", -1))
+			}
+			rows = append(rows, header)
+		}
+		for _, item := range items(data) {
+			if item.CanAddr() {
+				highlighter, ok := item.Addr().Interface().(Highlighter)
+				if ok && highlighter.Highlighted() {
+					highlighted[len(rows)] = true
+				}
+			}
+			rows = append(rows, fieldValues(item))
+		}
+	} else {
+		values := fieldValues(items(data)[0])
+		for i, name := range names {
+			rows = append(rows, []string{name, values[i]})
+		}
+	}
+
+	if !tty {
+		for _, row := range rows {
+			_, err := fmt.Fprintln(w, strings.Join(row, "\t"))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	bold := color.New(color.Bold)
+	for i, line := range lines {
+		if i == 0 && isSlice(data) {
+			line = bold.Sprint(strings.TrimSuffix(line, "\n")) + "\n"
+		}
+		if highlighted[i] {
+			line = color.YellowString(strings.TrimSuffix(line, "\n")) + "\n"
+		}
+		_, err = io.WriteString(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSlice returns true if data is a slice or an array
+func isSlice(data interface{}) bool {
+	kind := reflect.Indirect(reflect.ValueOf(data)).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// items returns the structs on data
+func items(data interface{}) []reflect.Value {
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if !isSlice(data) {
+		return []reflect.Value{value}
+	}
+	result := make([]reflect.Value, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		result[i] = reflect.Indirect(value.Index(i))
+	}
+	return result
+}
+
+// itemType returns the struct type of the data items
+func itemType(data interface{}) reflect.Type {
+	dataType := reflect.TypeOf(data)
+	for dataType.Kind() == reflect.Ptr || dataType.Kind() == reflect.Slice || dataType.Kind() == reflect.Array {
+		dataType = dataType.Elem()
+	}
+	return dataType
+}
+
+// fieldNames returns the names of the exported fields of the data items,
+// taken from their json tag if there is one
+func fieldNames(data interface{}) []string {
+	dataType := itemType(data)
+	names := []string{}
+	for i := 0; i < dataType.NumField(); i++ {
+		field := dataType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// fieldValues returns the exported fields of a struct formatted as strings
+func fieldValues(item reflect.Value) []string {
+	values := []string{}
+	for i := 0; i < item.NumField(); i++ {
+		field := item.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		values = append(values, formatValue(item.Field(i)))
+	}
+	return values
+}
+
+// formatValue formats a field value, lists are joined by commas
+func formatValue(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		parts := make([]string, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			parts[i] = formatValue(value.Index(i))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/repejota/git-hub/output"
+)
+
+type item struct {
+	Number int      `json:"number" yaml:"number"`
+	Title  string   `json:"title" yaml:"title"`
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+var data = []*item{
+	{Number: 1, Title: "First", Labels: []string{"bug", "help wanted"}},
+	{Number: 2, Title: "Second, with comma", Labels: []string{}},
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		options  *output.Options
+		expected string
+	}{
+		{&output.Options{Format: output.FormatTable}, "1\tFirst\tbug, help wanted\n2\tSecond, with comma\t\n"},
+		{&output.Options{Format: output.FormatCSV}, "number,title,labels\n1,First,\"bug, help wanted\"\n2,\"Second, with comma\",\n"},
+		{&output.Options{Format: output.FormatYAML}, "- number: 1\n  title: First\n  labels:\n  - bug\n  - help wanted\n- number: 2\n  title: Second, with comma\n  labels: []\n"},
+		{&output.Options{Template: "#{{.Number}} {{.Title}}"}, "#1 First\n#2 Second, with comma\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		err := output.Render(&buf, test.options, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.expected {
+			t.Fatalf("Expected output %q but got %q", test.expected, buf.String())
+		}
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	expected := "{\n  \"number\": 1,\n  \"title\": \"First\",\n  \"labels\": [\n    \"bug\",\n    \"help wanted\"\n  ]\n}\n"
+	var buf bytes.Buffer
+	err := output.Render(&buf, &output.Options{Format: output.FormatJSON}, data[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Expected output %q but got %q", expected, buf.String())
+	}
+}
+
+func TestRenderTableSingleItem(t *testing.T) {
+	expected := "number\t1\ntitle\tFirst\nlabels\tbug, help wanted\n"
+	var buf bytes.Buffer
+	err := output.Render(&buf, &output.Options{Format: output.FormatTable}, data[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Expected output %q but got %q", expected, buf.String())
+	}
+}
+
+func TestRenderInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := output.Render(&buf, &output.Options{Format: "xml"}, data)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid format")
+	}
+}
+
+type highlightedItem struct {
+	Name  string `json:"name" yaml:"name"`
+	Stale bool   `json:"stale" yaml:"stale"`
+}
+
+func (i *highlightedItem) Highlighted() bool {
+	return i.Stale
+}
+
+func TestRenderTableHighlighted(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	highlighted := []*highlightedItem{{Name: "fresh"}, {Name: "old", Stale: true}}
+	expected := "\x1b[1mNAME   STALE\x1b[0m\nfresh  false\n\x1b[33mold    true\x1b[0m\n"
+	var buf bytes.Buffer
+	err := output.Render(&buf, &output.Options{Format: output.FormatTable, TTY: true}, highlighted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Expected output %q but got %q", expected, buf.String())
+	}
+}
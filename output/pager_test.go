@@ -0,0 +1,58 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package output_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/repejota/git-hub/output"
+)
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"one line", "one line\n"},
+		{"two\nlines\n", "two\nlines\n"},
+	}
+	for _, test := range tests {
+		file, err := ioutil.TempFile("", "git-hub-stdout")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout := os.Stdout
+		os.Stdout = file
+		err = output.Page(test.text)
+		os.Stdout = stdout
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadFile(file.Name())
+		os.Remove(file.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Fatalf("Expected output %q but got %q", test.expected, string(data))
+		}
+	}
+}
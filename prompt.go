@@ -0,0 +1,58 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Confirm asks a yes/no question on the terminal, anything but yes is no
+func Confirm(question string) (bool, error) {
+	fmt.Printf("%s [y/N] ", question)
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// Choose asks to pick one of the options on a numbered list and returns the
+// index of the chosen option
+func Choose(question string, options []string) (int, error) {
+	for i, option := range options {
+		fmt.Printf("%d) %s\n", i+1, option)
+	}
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("%s [1-%d] ", question, len(options))
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return 0, err
+		}
+		choice, err := strconv.Atoi(strings.TrimSpace(answer))
+		if err == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1, nil
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+// withStdin runs f reading its standard input from text
+func withStdin(t *testing.T, text string, f func()) {
+	file, err := ioutil.TempFile("", "git-hub-stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.Seek(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	defer func() {
+		os.Stdin = stdin
+		file.Close()
+	}()
+	os.Stdin = file
+	f()
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected bool
+	}{
+		{"y\n", true},
+		{"Yes\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"sure\n", false},
+		{"yes", true},
+	}
+	for _, test := range tests {
+		withStdin(t, test.answer, func() {
+			confirmed, err := ghub.Confirm("Continue?")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if confirmed != test.expected {
+				t.Fatalf("Expected %v for answer %q but got %v", test.expected, test.answer, confirmed)
+			}
+		})
+	}
+}
+
+func TestConfirmNoAnswer(t *testing.T) {
+	withStdin(t, "", func() {
+		_, err := ghub.Confirm("Continue?")
+		if err == nil {
+			t.Fatalf("Expected an error without an answer")
+		}
+	})
+}
+
+func TestChoose(t *testing.T) {
+	options := []string{"merge", "squash", "rebase"}
+	tests := []struct {
+		answer   string
+		expected int
+	}{
+		{"1\n", 0},
+		{"3\n", 2},
+		{"0\nfour\n4\n2\n", 1},
+	}
+	for _, test := range tests {
+		withStdin(t, test.answer, func() {
+			choice, err := ghub.Choose("Merge method", options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if choice != test.expected {
+				t.Fatalf("Expected choice %d for answer %q but got %d", test.expected, test.answer, choice)
+			}
+		})
+	}
+}
+
+func TestChooseNoValidAnswer(t *testing.T) {
+	withStdin(t, "7\n", func() {
+		_, err := ghub.Choose("Merge method", []string{"merge"})
+		if err == nil {
+			t.Fatalf("Expected an error without a valid answer")
+		}
+	})
+}
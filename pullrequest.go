@@ -0,0 +1,618 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// Checks states
+const (
+	ChecksSuccess = "success"
+	ChecksPending = "pending"
+	ChecksFailure = "failure"
+)
+
+// PullRequestStatus ...
+type PullRequestStatus struct {
+	// Checks is the combined state of the statuses and check runs of the
+	// pull request head, success, pending or failure
+	Checks string
+	// Approvals is the number of reviewers whose latest review approves
+	Approvals int
+	// ChangesRequested is true if any reviewer latest review requests
+	// changes
+	ChangesRequested bool
+	// CheckStates are the states of each status context and check run of
+	// the pull request head, by name
+	CheckStates map[string]string
+}
+
+// MergeRequirements are the required status checks and approvals of the
+// base branch protection of a pull request
+type MergeRequirements struct {
+	// Checks are the status check contexts that must succeed
+	Checks []string
+	// Approvals is the number of approving reviews required
+	Approvals int
+}
+
+// Approved returns true if the pull request has approvals and no changes
+// requested
+func (s *PullRequestStatus) Approved() bool {
+	return s.Approvals > 0 && !s.ChangesRequested
+}
+
+// RequiredChecks returns the combined state of the required status checks,
+// missing checks are pending
+func (s *PullRequestStatus) RequiredChecks(requirements *MergeRequirements) string {
+	states := map[string]string{}
+	for _, name := range requirements.Checks {
+		state, ok := s.CheckStates[name]
+		if !ok {
+			state = ChecksPending
+		}
+		states[name] = state
+	}
+	return combineChecksStates(states)
+}
+
+// MergeBlockers returns why the pull request can not be merged yet given the
+// merge requirements, it is empty when it can be merged
+func (s *PullRequestStatus) MergeBlockers(requirements *MergeRequirements) []string {
+	blockers := []string{}
+	if checks := s.RequiredChecks(requirements); checks != ChecksSuccess {
+		blockers = append(blockers, fmt.Sprintf("required checks are %s", checks))
+	}
+	if requirements.Approvals > 0 {
+		if s.ChangesRequested {
+			blockers = append(blockers, "changes are requested")
+		}
+		if s.Approvals < requirements.Approvals {
+			blockers = append(blockers, fmt.Sprintf("%d of %d required approvals", s.Approvals, requirements.Approvals))
+		}
+	}
+	return blockers
+}
+
+// GetMergeRequirements returns the required status checks and approvals of
+// a branch protection, none if the branch is not protected
+func GetMergeRequirements(ctx context.Context, client *github.Client, organization string, repository string, branchName string) (*MergeRequirements, error) {
+	requirements := &MergeRequirements{
+		Checks: []string{},
+	}
+	protection, response, err := client.Repositories.GetBranchProtection(ctx, organization, repository, branchName)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return requirements, nil
+		}
+		return nil, err
+	}
+	if protection.RequiredStatusChecks != nil {
+		requirements.Checks = protection.RequiredStatusChecks.Contexts
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		requirements.Approvals = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	return requirements, nil
+}
+
+// CreatePullRequest ...
+func CreatePullRequest(ctx context.Context, client *github.Client, organization string, repository string, newPullRequest *github.NewPullRequest) (*github.PullRequest, error) {
+	pullRequest, _, err := client.PullRequests.Create(ctx, organization, repository, newPullRequest)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// CreateDraftPullRequest creates a pull request as a draft
+func CreateDraftPullRequest(ctx context.Context, client *github.Client, organization string, repository string, newPullRequest *github.NewPullRequest) (*github.PullRequest, error) {
+	body := struct {
+		*github.NewPullRequest
+		Draft bool `json:"draft"`
+	}{newPullRequest, true}
+	url := fmt.Sprintf("repos/%s/%s/pulls", organization, repository)
+	request, err := client.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	pullRequest := &github.PullRequest{}
+	_, err = client.Do(ctx, request, pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// IssueReference returns how an issue is referenced from a repository, #12
+// on the same repository and org/repo#12 on another one
+func IssueReference(number int, issueRepositoryFullName string, repositoryFullName string) string {
+	if issueRepositoryFullName == "" || strings.EqualFold(issueRepositoryFullName, repositoryFullName) {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("%s#%d", issueRepositoryFullName, number)
+}
+
+// CreateIssueDraftPullRequest opens a draft pull request from an issue
+// branch, titled after the issue, closing it and with its labels, and links
+// it on an issue comment. The issue may belong to another repository.
+func CreateIssueDraftPullRequest(ctx context.Context, client *github.Client, organization string, repository string, issue *github.Issue, issueRepositoryFullName string, branchName string, baseBranchName string) (*github.PullRequest, error) {
+	repositoryFullName := fmt.Sprintf("%s/%s", organization, repository)
+	title := issue.GetTitle()
+	body := fmt.Sprintf("Closes %s", IssueReference(issue.GetNumber(), issueRepositoryFullName, repositoryFullName))
+	pullRequest, err := CreateDraftPullRequest(ctx, client, organization, repository, &github.NewPullRequest{
+		Title: &title,
+		Head:  &branchName,
+		Base:  &baseBranchName,
+		Body:  &body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []string{}
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+	if len(labels) > 0 {
+		err = AddLabelsToIssue(ctx, client, organization, repository, pullRequest.GetNumber(), labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	issueOrganization, issueRepository := organization, repository
+	if issueRepositoryFullName != "" {
+		issueOrganization, issueRepository = ParseRepositoryFullName(issueRepositoryFullName)
+	}
+	comment := fmt.Sprintf("Work in progress on %s", IssueReference(pullRequest.GetNumber(), repositoryFullName, issueRepositoryFullName))
+	_, err = CommentIssue(ctx, client, issueOrganization, issueRepository, issue.GetNumber(), comment)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// RequestReviewers asks users to review a pull request
+func RequestReviewers(ctx context.Context, client *github.Client, organization string, repository string, number int, reviewers []string) error {
+	_, _, err := client.PullRequests.RequestReviewers(ctx, organization, repository, number, github.ReviewersRequest{
+		Reviewers: reviewers,
+	})
+	return err
+}
+
+// pullRequestTemplatePaths are the places GitHub looks for a pull request
+// template, relative to the repository root
+var pullRequestTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// LoadPullRequestTemplate returns the pull request template of a repository
+// or an empty string if it has none
+func LoadPullRequestTemplate(path string) (string, error) {
+	for _, templatePath := range pullRequestTemplatePaths {
+		data, err := ioutil.ReadFile(filepath.Join(path, templatePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", nil
+}
+
+// PullRequestTitle returns a default title for a pull request, the commit
+// subject when there is only one commit or the branch name in words
+func PullRequestTitle(branchName string, subjects []string) string {
+	if len(subjects) == 1 {
+		return subjects[0]
+	}
+	slug := branchName
+	if i := strings.Index(slug, "/"); i >= 0 {
+		slug = slug[i+1:]
+	}
+	if issueBranch, err := ParseIssueBranch(branchName); err == nil && issueBranch.Slug != "" {
+		slug = issueBranch.Slug
+	}
+	title := strings.Replace(slug, "-", " ", -1)
+	if title == "" {
+		return branchName
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// PullRequestBody returns a default body for a pull request listing its
+// commit subjects, oldest first
+func PullRequestBody(subjects []string) string {
+	lines := []string{}
+	for i := len(subjects) - 1; i >= 0; i-- {
+		lines = append(lines, fmt.Sprintf("- %s", subjects[i]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Pull request states
+const (
+	PullRequestStateOpen   = "open"
+	PullRequestStateClosed = "closed"
+	PullRequestStateMerged = "merged"
+	PullRequestStateAll    = "all"
+)
+
+// PullRequestListOptions ...
+type PullRequestListOptions struct {
+	// State is open, closed, merged or all
+	State           string
+	Author          string
+	ReviewRequested string
+	Labels          []string
+	Base            string
+	// Limit is the maximum number of pull requests to return, zero returns
+	// all
+	Limit int
+}
+
+// PullRequestSearchQuery returns the search query for the pull requests of
+// a repository matching the options
+func PullRequestSearchQuery(organization string, repository string, options *PullRequestListOptions) string {
+	terms := []string{fmt.Sprintf("repo:%s/%s", organization, repository), "is:pr"}
+	switch options.State {
+	case PullRequestStateOpen, PullRequestStateClosed:
+		terms = append(terms, "state:"+options.State)
+	case PullRequestStateMerged:
+		terms = append(terms, "is:merged")
+	}
+	if options.Author != "" {
+		terms = append(terms, "author:"+options.Author)
+	}
+	if options.ReviewRequested != "" {
+		terms = append(terms, "review-requested:"+options.ReviewRequested)
+	}
+	for _, label := range options.Labels {
+		terms = append(terms, fmt.Sprintf("label:%q", label))
+	}
+	if options.Base != "" {
+		terms = append(terms, "base:"+options.Base)
+	}
+	return strings.Join(terms, " ")
+}
+
+// ListPullRequests returns the pull requests of a repository matching the
+// options, as issues, following all the result pages up to the options
+// limit.
+func ListPullRequests(ctx context.Context, client *github.Client, organization string, repository string, options *PullRequestListOptions) ([]*github.Issue, error) {
+	searchOptions := &github.SearchOptions{
+		Sort:        "created",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if options.Limit > 0 && options.Limit < searchOptions.PerPage {
+		searchOptions.PerPage = options.Limit
+	}
+	query := PullRequestSearchQuery(organization, repository, options)
+	pullRequests := []*github.Issue{}
+	for {
+		result, response, err := client.Search.Issues(ctx, query, searchOptions)
+		if err != nil {
+			return nil, err
+		}
+		for i := range result.Issues {
+			pullRequests = append(pullRequests, &result.Issues[i])
+			if options.Limit > 0 && len(pullRequests) == options.Limit {
+				return pullRequests, nil
+			}
+		}
+		if response.NextPage == 0 {
+			return pullRequests, nil
+		}
+		searchOptions.Page = response.NextPage
+	}
+}
+
+// GetPullRequest ...
+func GetPullRequest(ctx context.Context, client *github.Client, organization string, repository string, number int) (*github.PullRequest, error) {
+	pullRequest, _, err := client.PullRequests.Get(ctx, organization, repository, number)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// ListPullRequestFiles returns the files changed by a pull request
+func ListPullRequestFiles(ctx context.Context, client *github.Client, organization string, repository string, number int) ([]*github.CommitFile, error) {
+	options := &github.ListOptions{PerPage: 100}
+	files := []*github.CommitFile{}
+	for {
+		page, response, err := client.PullRequests.ListFiles(ctx, organization, repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, page...)
+		if response.NextPage == 0 {
+			return files, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// ListLatestReviews returns the state of the latest review of each
+// reviewer of a pull request, comments are not counted as reviews
+func ListLatestReviews(ctx context.Context, client *github.Client, organization string, repository string, number int) (map[string]string, error) {
+	latestReviews := map[string]string{}
+	options := &github.ListOptions{}
+	for {
+		reviews, response, err := client.PullRequests.ListReviews(ctx, organization, repository, number, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			if review.GetState() == "COMMENTED" {
+				continue
+			}
+			latestReviews[review.GetUser().GetLogin()] = review.GetState()
+		}
+		if response.NextPage == 0 {
+			return latestReviews, nil
+		}
+		options.Page = response.NextPage
+	}
+}
+
+// PullRequestBranchName returns the local branch name to check out a pull
+// request, its head branch name, prefixed by the fork owner for pull
+// requests from forks
+func PullRequestBranchName(pullRequest *github.PullRequest) string {
+	head := pullRequest.GetHead()
+	if head.Repo == nil {
+		// The fork was deleted
+		return fmt.Sprintf("pull/%d", pullRequest.GetNumber())
+	}
+	if head.GetRepo().GetFullName() == pullRequest.GetBase().GetRepo().GetFullName() {
+		return head.GetRef()
+	}
+	return fmt.Sprintf("%s/%s", head.GetRepo().GetOwner().GetLogin(), head.GetRef())
+}
+
+// FindOpenPullRequest returns the open pull request for a head branch, or
+// nil if there is none.
+func FindOpenPullRequest(ctx context.Context, client *github.Client, organization string, repository string, branchName string) (*github.PullRequest, error) {
+	options := &github.PullRequestListOptions{
+		State: "open",
+		Head:  fmt.Sprintf("%s:%s", organization, branchName),
+	}
+	pullRequests, _, err := client.PullRequests.List(ctx, organization, repository, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(pullRequests) == 0 {
+		return nil, nil
+	}
+	return pullRequests[0], nil
+}
+
+// GetChecksState returns the combined state of the commit statuses and check
+// runs of a ref, success, pending or failure. A ref without checks is
+// considered successful.
+func GetChecksState(ctx context.Context, client *github.Client, organization string, repository string, ref string) (string, error) {
+	states, err := GetCheckStates(ctx, client, organization, repository, ref)
+	if err != nil {
+		return "", err
+	}
+	return combineChecksStates(states), nil
+}
+
+// GetCheckStates returns the state of each commit status context and check
+// run of a ref by name, success, pending or failure
+func GetCheckStates(ctx context.Context, client *github.Client, organization string, repository string, ref string) (map[string]string, error) {
+	states := map[string]string{}
+
+	combinedStatus, _, err := client.Repositories.GetCombinedStatus(ctx, organization, repository, ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range combinedStatus.Statuses {
+		switch status.GetState() {
+		case "success":
+			states[status.GetContext()] = ChecksSuccess
+		case "pending":
+			states[status.GetContext()] = ChecksPending
+		default:
+			states[status.GetContext()] = ChecksFailure
+		}
+	}
+
+	checkRuns, _, err := client.Checks.ListCheckRunsForRef(ctx, organization, repository, ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, checkRun := range checkRuns.CheckRuns {
+		if checkRun.GetStatus() != "completed" {
+			states[checkRun.GetName()] = ChecksPending
+			continue
+		}
+		switch checkRun.GetConclusion() {
+		case "success", "neutral", "skipped":
+			states[checkRun.GetName()] = ChecksSuccess
+		default:
+			states[checkRun.GetName()] = ChecksFailure
+		}
+	}
+
+	return states, nil
+}
+
+// combineChecksStates returns failure if any check failed, pending if any
+// is pending and success otherwise
+func combineChecksStates(states map[string]string) string {
+	state := ChecksSuccess
+	for _, checkState := range states {
+		switch checkState {
+		case ChecksFailure:
+			return ChecksFailure
+		case ChecksPending:
+			state = ChecksPending
+		}
+	}
+	return state
+}
+
+// GetPullRequestStatus returns the checks and review status of a pull
+// request
+func GetPullRequestStatus(ctx context.Context, client *github.Client, organization string, repository string, pullRequest *github.PullRequest) (*PullRequestStatus, error) {
+	checkStates, err := GetCheckStates(ctx, client, organization, repository, pullRequest.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	status := &PullRequestStatus{
+		Checks:      combineChecksStates(checkStates),
+		CheckStates: checkStates,
+	}
+
+	// Only the latest review of each reviewer counts
+	latestReviews, err := ListLatestReviews(ctx, client, organization, repository, pullRequest.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range latestReviews {
+		switch state {
+		case "APPROVED":
+			status.Approvals++
+		case "CHANGES_REQUESTED":
+			status.ChangesRequested = true
+		}
+	}
+
+	return status, nil
+}
+
+// Merge methods
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergePullRequest merges a pull request and returns the merge commit SHA,
+// an empty commit title or message lets GitHub compose them
+func MergePullRequest(ctx context.Context, client *github.Client, organization string, repository string, pullRequest *github.PullRequest, commitTitle string, commitMessage string, mergeMethod string) (string, error) {
+	// The github package always sends the commit message, even empty
+	body := map[string]string{
+		"merge_method": mergeMethod,
+	}
+	if pullRequest.GetHead().GetSHA() != "" {
+		body["sha"] = pullRequest.GetHead().GetSHA()
+	}
+	if commitTitle != "" {
+		body["commit_title"] = commitTitle
+	}
+	if commitMessage != "" {
+		body["commit_message"] = commitMessage
+	}
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/merge", organization, repository, pullRequest.GetNumber())
+	request, err := client.NewRequest("PUT", url, body)
+	if err != nil {
+		return "", err
+	}
+	result := &github.PullRequestMergeResult{}
+	_, err = client.Do(ctx, request, result)
+	if err != nil {
+		return "", err
+	}
+	if !result.GetMerged() {
+		return "", fmt.Errorf("Pull request #%d was not merged: %s", pullRequest.GetNumber(), result.GetMessage())
+	}
+	return result.GetSHA(), nil
+}
+
+// htmlCommentRegexp matches the HTML comments of pull request templates
+var htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// SquashCommitMessage returns the title and message of the commit squashing
+// a pull request, from its title and body. Issue branches get a closing
+// reference to their issue if the body has none.
+func SquashCommitMessage(pullRequest *github.PullRequest) (string, string) {
+	title := fmt.Sprintf("%s (#%d)", pullRequest.GetTitle(), pullRequest.GetNumber())
+	message := strings.TrimSpace(htmlCommentRegexp.ReplaceAllString(pullRequest.GetBody(), ""))
+
+	issueBranch, err := ParseIssueBranch(pullRequest.GetHead().GetRef())
+	if err == nil && issueBranch.RepositorySlug == "" {
+		closing := regexp.MustCompile(fmt.Sprintf(`(?i)\b(close[sd]?|fix(e[sd])?|resolve[sd]?):?\s+#%d\b`, issueBranch.Number))
+		if !closing.MatchString(message) {
+			message = strings.TrimSpace(fmt.Sprintf("%s\n\nCloses #%d", message, issueBranch.Number))
+		}
+	}
+	return title, message
+}
+
+// WaitForPullRequest polls a pull request until it has the approvals and
+// succeeded checks its base branch protection requires. It fails if the
+// required checks fail, the pull request is closed or the timeout expires, a
+// zero timeout waits forever.
+func WaitForPullRequest(ctx context.Context, client *github.Client, organization string, repository string, number int, interval time.Duration, timeout time.Duration) (*github.PullRequest, error) {
+	deadline := time.Now().Add(timeout)
+	var requirements *MergeRequirements
+	for {
+		pullRequest, err := GetPullRequest(ctx, client, organization, repository, number)
+		if err != nil {
+			return nil, err
+		}
+		if pullRequest.GetState() != "open" {
+			return nil, fmt.Errorf("Pull request #%d is %s", number, pullRequest.GetState())
+		}
+		if requirements == nil {
+			requirements, err = GetMergeRequirements(ctx, client, organization, repository, pullRequest.GetBase().GetRef())
+			if err != nil {
+				return nil, err
+			}
+		}
+		status, err := GetPullRequestStatus(ctx, client, organization, repository, pullRequest)
+		if err != nil {
+			return nil, err
+		}
+		if status.RequiredChecks(requirements) == ChecksFailure {
+			return nil, fmt.Errorf("Pull request #%d required checks failed", number)
+		}
+		blockers := status.MergeBlockers(requirements)
+		if len(blockers) == 0 {
+			return pullRequest, nil
+		}
+		log.Printf("Pull request #%d %s\n", number, strings.Join(blockers, ", "))
+		if timeout > 0 && time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("Timed out waiting for pull request #%d", number)
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub"
+)
+
+func TestPullRequestStatusApproved(t *testing.T) {
+	tests := []struct {
+		status   *ghub.PullRequestStatus
+		expected bool
+	}{
+		{&ghub.PullRequestStatus{Approvals: 0}, false},
+		{&ghub.PullRequestStatus{Approvals: 1}, true},
+		{&ghub.PullRequestStatus{Approvals: 2, ChangesRequested: true}, false},
+	}
+	for _, test := range tests {
+		if test.status.Approved() != test.expected {
+			t.Fatalf("Expected approved to be %t for %+v", test.expected, test.status)
+		}
+	}
+}
+
+func TestPullRequestTitle(t *testing.T) {
+	tests := []struct {
+		branchName string
+		subjects   []string
+		expected   string
+	}{
+		{"issue/12-fix-the-thing", []string{"Fix the thing for good"}, "Fix the thing for good"},
+		{"issue/12-fix-the-thing", []string{"Second", "First"}, "Fix the thing"},
+		{"feature/dark-mode", []string{}, "Dark mode"},
+		{"wip", []string{"Second", "First"}, "Wip"},
+	}
+	for _, test := range tests {
+		title := ghub.PullRequestTitle(test.branchName, test.subjects)
+		if title != test.expected {
+			t.Fatalf("Expected title %q but got %q", test.expected, title)
+		}
+	}
+}
+
+func TestPullRequestBody(t *testing.T) {
+	expected := "- First\n- Second"
+
+	body := ghub.PullRequestBody([]string{"Second", "First"})
+	if body != expected {
+		t.Fatalf("Expected body %q but got %q", expected, body)
+	}
+}
+
+func TestIssueReference(t *testing.T) {
+	tests := []struct {
+		issueRepositoryFullName string
+		expected                string
+	}{
+		{"", "#12"},
+		{"repejota/git-hub", "#12"},
+		{"Repejota/Git-Hub", "#12"},
+		{"repejota/other", "repejota/other#12"},
+	}
+	for _, test := range tests {
+		reference := ghub.IssueReference(12, test.issueRepositoryFullName, "repejota/git-hub")
+		if reference != test.expected {
+			t.Fatalf("Expected reference %q but got %q", test.expected, reference)
+		}
+	}
+}
+
+func TestPullRequestSearchQuery(t *testing.T) {
+	tests := []struct {
+		options  *ghub.PullRequestListOptions
+		expected string
+	}{
+		{&ghub.PullRequestListOptions{State: ghub.PullRequestStateOpen}, "repo:repejota/git-hub is:pr state:open"},
+		{&ghub.PullRequestListOptions{State: ghub.PullRequestStateMerged, Base: "master"}, "repo:repejota/git-hub is:pr is:merged base:master"},
+		{&ghub.PullRequestListOptions{State: ghub.PullRequestStateAll, Author: "repejota", ReviewRequested: "octocat", Labels: []string{"good first issue"}}, `repo:repejota/git-hub is:pr author:repejota review-requested:octocat label:"good first issue"`},
+	}
+	for _, test := range tests {
+		query := ghub.PullRequestSearchQuery("repejota", "git-hub", test.options)
+		if query != test.expected {
+			t.Fatalf("Expected query %q but got %q", test.expected, query)
+		}
+	}
+}
+
+func TestPullRequestBranchName(t *testing.T) {
+	number := 7
+	ref := "fix-docs"
+	baseFullName := "repejota/git-hub"
+	forkFullName := "octocat/git-hub"
+	forkOwner := "octocat"
+	base := &github.PullRequestBranch{Repo: &github.Repository{FullName: &baseFullName}}
+	tests := []struct {
+		head     *github.PullRequestBranch
+		expected string
+	}{
+		{&github.PullRequestBranch{Ref: &ref, Repo: &github.Repository{FullName: &baseFullName}}, "fix-docs"},
+		{&github.PullRequestBranch{Ref: &ref, Repo: &github.Repository{FullName: &forkFullName, Owner: &github.User{Login: &forkOwner}}}, "octocat/fix-docs"},
+		{&github.PullRequestBranch{Ref: &ref}, "pull/7"},
+	}
+	for _, test := range tests {
+		pullRequest := &github.PullRequest{Number: &number, Head: test.head, Base: base}
+		branchName := ghub.PullRequestBranchName(pullRequest)
+		if branchName != test.expected {
+			t.Fatalf("Expected branch name %q but got %q", test.expected, branchName)
+		}
+	}
+}
+
+func TestSquashCommitMessage(t *testing.T) {
+	number := 15
+	title := "Fix the thing"
+	tests := []struct {
+		ref             string
+		body            string
+		expectedMessage string
+	}{
+		{"issue/12-fix-the-thing", "Fixes #12 for good", "Fixes #12 for good"},
+		{"issue/12-fix-the-thing", "<!-- Describe the change -->\nA better thing", "A better thing\n\nCloses #12"},
+		{"issue/12-fix-the-thing", "", "Closes #12"},
+		{"feature/thing", "A thing", "A thing"},
+	}
+	for _, test := range tests {
+		ref := test.ref
+		body := test.body
+		pullRequest := &github.PullRequest{
+			Number: &number,
+			Title:  &title,
+			Body:   &body,
+			Head:   &github.PullRequestBranch{Ref: &ref},
+		}
+		commitTitle, message := ghub.SquashCommitMessage(pullRequest)
+		if commitTitle != "Fix the thing (#15)" {
+			t.Fatalf("Expected title %q but got %q", "Fix the thing (#15)", commitTitle)
+		}
+		if message != test.expectedMessage {
+			t.Fatalf("Expected message %q but got %q", test.expectedMessage, message)
+		}
+	}
+}
+
+func TestPullRequestStatusMergeBlockers(t *testing.T) {
+	tests := []struct {
+		status       *ghub.PullRequestStatus
+		requirements *ghub.MergeRequirements
+		expected     []string
+	}{
+		{&ghub.PullRequestStatus{CheckStates: map[string]string{"ci": "failure"}}, &ghub.MergeRequirements{}, []string{}},
+		{&ghub.PullRequestStatus{CheckStates: map[string]string{"ci": "success", "lint": "failure"}, Approvals: 1}, &ghub.MergeRequirements{Checks: []string{"ci"}, Approvals: 1}, []string{}},
+		{&ghub.PullRequestStatus{CheckStates: map[string]string{"ci": "failure"}}, &ghub.MergeRequirements{Checks: []string{"ci"}}, []string{"required checks are failure"}},
+		{&ghub.PullRequestStatus{CheckStates: map[string]string{}}, &ghub.MergeRequirements{Checks: []string{"ci"}}, []string{"required checks are pending"}},
+		{&ghub.PullRequestStatus{Approvals: 1}, &ghub.MergeRequirements{Approvals: 2}, []string{"1 of 2 required approvals"}},
+		{&ghub.PullRequestStatus{Approvals: 2, ChangesRequested: true}, &ghub.MergeRequirements{Approvals: 2}, []string{"changes are requested"}},
+		{&ghub.PullRequestStatus{ChangesRequested: true}, &ghub.MergeRequirements{}, []string{}},
+	}
+	for _, test := range tests {
+		blockers := test.status.MergeBlockers(test.requirements)
+		if !reflect.DeepEqual(blockers, test.expected) {
+			t.Fatalf("Expected blockers %q for %+v and %+v but got %q", test.expected, test.status, test.requirements, blockers)
+		}
+	}
+}
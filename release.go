@@ -18,16 +18,40 @@
 package ghub
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/google/go-github/github"
 	"github.com/repejota/git-hub/automation"
 )
 
+// ReleaseOptions ...
+type ReleaseOptions struct {
+	// Major bumps the major version instead of the patch version
+	Major bool
+	// GoModule rewrites the Go module path and its imports to match the
+	// next major version
+	GoModule bool
+	// PullRequest releases through a pull request into the default branch
+	// instead of pushing to it, also enabled with git-hub.releasePullRequest
+	PullRequest bool
+	// Timeout is how long to wait for the release pull request to be
+	// approved and its checks to succeed, zero waits forever
+	Timeout time.Duration
+	// Version is the version to release instead of the computed next
+	// version
+	Version string
+}
+
 // ReleaseStart ...
-func ReleaseStart(path string, gitHubToken string) {
+func ReleaseStart(path string, gitHubToken string, options *ReleaseOptions) {
 	// Open repository
 	repository, err := OpenRepository(path, gitHubToken)
 	if err != nil {
@@ -54,7 +78,14 @@ func ReleaseStart(path string, gitHubToken string) {
 	fmt.Println(out)
 
 	// Calculate new version
-	nextVersion, err := repository.NextVersion()
+	var nextVersion Version
+	if options.Version != "" {
+		nextVersion, err = repository.ParseVersion(options.Version)
+	} else if options.Major {
+		nextVersion, err = repository.NextMajorVersion()
+	} else {
+		nextVersion, err = repository.NextVersion()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -69,23 +100,93 @@ func ReleaseStart(path string, gitHubToken string) {
 	fmt.Printf("Created local branch: %s\n", releaseBranchName)
 	fmt.Println(out)
 
-	// Push local release branch to origin
-	out, err = automation.PushLocalBranchToOrigin(releaseBranchName)
+	// Rewrite Go module path
+	changedFiles := []string{}
+	if options.GoModule {
+		semver, ok := nextVersion.(*SemVer)
+		if !ok {
+			log.Fatalf("Go module major versions require SemVer and next version is %q", nextVersion)
+		}
+		changedFiles, err = UpdateGoModuleMajorVersion(path, semver.Major)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, changedFile := range changedFiles {
+			fmt.Println("Rewrote Go module path on", changedFile)
+		}
+	}
+
+	// Bump nextVersion
+	out, err = automation.BumpNextVersion(nextVersion.String(), changedFiles...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	fmt.Println(out)
 
-	// Bump nextVersion
-	out, err = automation.BumpNextVersion(nextVersion.String())
+	// Push local release branch to origin, with the bump commit
+	out, err = automation.PushLocalBranchToOrigin(releaseBranchName)
 	if err != nil {
 		log.Fatal(err)
 	}
 	fmt.Println(out)
+
+	// Open the release pull request
+	if !releaseWithPullRequest(options) {
+		return
+	}
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, repository.GitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	previousTagName, err := automation.GetPreviousTag("HEAD")
+	if err != nil {
+		log.Fatal(err)
+	}
+	notes, err := ReleaseNotes(previousTagName, "HEAD")
+	if err != nil {
+		log.Fatal(err)
+	}
+	title := fmt.Sprintf("Release %s", nextVersion)
+	baseBranchName := repository.GitHubRepository.GetDefaultBranch()
+	pullRequest, err := CreatePullRequest(ctx, client, org, repo, &github.NewPullRequest{
+		Title: &title,
+		Head:  &releaseBranchName,
+		Base:  &baseBranchName,
+		Body:  &notes,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Opened release pull request", pullRequest.GetHTMLURL())
+}
+
+// ReleaseNotes returns the notes of a release in Markdown, listing the
+// commits between two revisions.
+func ReleaseNotes(from string, to string) (string, error) {
+	subjects, err := automation.GetCommitSubjects(from, to)
+	if err != nil {
+		return "", err
+	}
+	notes := "## Changes\n\n"
+	for _, subject := range subjects {
+		notes = fmt.Sprintf("%s- %s\n", notes, subject)
+	}
+	return notes, nil
+}
+
+// releaseWithPullRequest returns true if releases go through a pull request
+func releaseWithPullRequest(options *ReleaseOptions) bool {
+	if options.PullRequest {
+		return true
+	}
+	releasePullRequest, err := automation.GetConfig("releasePullRequest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return releasePullRequest == "true"
 }
 
 // ReleaseFinish ...
-func ReleaseFinish(path string, gitHubToken string) {
+func ReleaseFinish(path string, gitHubToken string, options *ReleaseOptions) {
 	// Open repository
 	repository, err := OpenRepository(path, gitHubToken)
 	if err != nil {
@@ -100,6 +201,11 @@ func ReleaseFinish(path string, gitHubToken string) {
 	}
 	fmt.Println("Finishing release", releaseBranchName)
 
+	if releaseWithPullRequest(options) {
+		releaseFinishPullRequest(repository, releaseBranchName, options)
+		return
+	}
+
 	// Go to master branch
 	out, err := automation.GoGitBranch("master")
 	if err != nil {
@@ -167,4 +273,372 @@ func ReleaseFinish(path string, gitHubToken string) {
 	}
 	fmt.Println("Deleting local branch", releaseBranchName)
 	fmt.Println(out)
+
+	// Notify shipped issues and close the release milestone
+	err = ReleaseNotify(repository, currentVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// releaseFinishPullRequest merges the release pull request once it is
+// approved and its checks succeed, then tags the merge commit and cleans up
+// the release branch.
+func releaseFinishPullRequest(repository *Repository, releaseBranchName string, options *ReleaseOptions) {
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, repository.GitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+
+	// Find the release pull request
+	pullRequest, err := FindOpenPullRequest(ctx, client, org, repo, releaseBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if pullRequest == nil {
+		log.Fatalf("There is no open pull request for branch %q", releaseBranchName)
+	}
+
+	// Wait for approvals and green checks
+	fmt.Println("Waiting for approvals and checks on", pullRequest.GetHTMLURL())
+	pullRequest, err = WaitForPullRequest(ctx, client, org, repo, pullRequest.GetNumber(), 30*time.Second, options.Timeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Merge the pull request
+	commitTitle := fmt.Sprintf("Merge %s into %s", releaseBranchName, defaultBranchName)
+	mergeCommitSHA, err := MergePullRequest(ctx, client, org, repo, pullRequest, commitTitle, "", MergeMethodMerge)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Merged pull request #%d as %s\n", pullRequest.GetNumber(), mergeCommitSHA)
+
+	// Go to the default branch
+	out, err := automation.GoGitBranch(defaultBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Checking out", defaultBranchName, "branch")
+	fmt.Println(out)
+
+	// Pull the merge commit
+	out, err = automation.PullAndRebase()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Pull and rebase", defaultBranchName, "branch")
+	fmt.Println(out)
+
+	// Tag the merge commit
+	currentVersion, err := repository.GetCurrentVersion()
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err = automation.CreateGitTagAt(currentVersion.String(), mergeCommitSHA)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Creating a local tag", currentVersion)
+	fmt.Println(out)
+
+	// Push tags
+	out, err = automation.GitPushTags()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Pushing tag", currentVersion)
+	fmt.Println(out)
+
+	// Delete remote release branch, it may have been deleted on merge
+	out, err = automation.DeleteRemoteBranch(releaseBranchName)
+	if err != nil {
+		fmt.Println(color.YellowString("WARNING: remote branch %s not deleted: %s", releaseBranchName, err.Error()))
+	} else {
+		fmt.Println("Deleting remote branch", releaseBranchName)
+		fmt.Println(out)
+	}
+
+	// Delete local release branch
+	out, err = automation.DeleteLocalBranch(releaseBranchName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Deleting local branch", releaseBranchName)
+	fmt.Println(out)
+
+	// Notify shipped issues and close the release milestone
+	err = ReleaseNotify(repository, currentVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// ReleaseNotify comments and labels the issues and pull requests shipped on
+// a release, closes the milestone named after the release version and, if
+// configured, creates the milestone for the next version.
+func ReleaseNotify(repository *Repository, version Version) error {
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, repository.GitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	tagName := version.String()
+	releaseURL := fmt.Sprintf("%s/releases/tag/%s", repository.GitHubRepository.GetHTMLURL(), tagName)
+
+	// Issues referenced by merged issue branches since the previous tag
+	previousTagName, err := automation.GetPreviousTag(tagName)
+	if err != nil {
+		return err
+	}
+	messages, err := automation.GetMergeCommitMessages(previousTagName, tagName)
+	if err != nil {
+		return err
+	}
+	issueNumbers := ParseMergedIssueNumbers(messages)
+
+	// Issues and pull requests closed since the previous tag by a commit of
+	// the release
+	if previousTagName != "" {
+		since, err := automation.GetCommitDate(previousTagName)
+		if err != nil {
+			return err
+		}
+		hashes, err := automation.GetCommitHashes(previousTagName, tagName)
+		if err != nil {
+			return err
+		}
+		released := map[string]bool{}
+		for _, hash := range hashes {
+			released[hash] = true
+		}
+		issues, err := ListIssuesClosedSince(ctx, client, org, repo, since)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			events, err := ListIssueTimeline(ctx, client, org, repo, issue.GetNumber())
+			if err != nil {
+				return err
+			}
+			if !released[ClosingCommitID(events)] {
+				continue
+			}
+			issueNumbers = append(issueNumbers, issue.GetNumber())
+		}
+	}
+
+	releasedLabel, err := automation.GetConfig("releasedLabel")
+	if err != nil {
+		return err
+	}
+	if releasedLabel == "" {
+		releasedLabel = "released"
+	}
+
+	notified := map[int]bool{}
+	for _, issueNumber := range issueNumbers {
+		if notified[issueNumber] {
+			continue
+		}
+		notified[issueNumber] = true
+
+		body := fmt.Sprintf("Released in [%s](%s)", tagName, releaseURL)
+		_, err = CommentIssue(ctx, client, org, repo, issueNumber, body)
+		if err != nil {
+			return err
+		}
+		err = AddLabelsToIssue(ctx, client, org, repo, issueNumber, []string{releasedLabel})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Notified #%d released in %s\n", issueNumber, tagName)
+	}
+
+	// Close the release milestone
+	milestone, err := GetMilestoneByTitle(ctx, client, org, repo, tagName)
+	if err != nil {
+		return err
+	}
+	if milestone == nil {
+		milestone, err = GetMilestoneByTitle(ctx, client, org, repo, "v"+tagName)
+		if err != nil {
+			return err
+		}
+	}
+	if milestone != nil && milestone.GetState() == "open" {
+		err = CloseMilestone(ctx, client, org, repo, milestone)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Closed milestone", milestone.GetTitle())
+	}
+
+	// Create the next milestone
+	nextMilestone, err := automation.GetConfig("nextMilestone")
+	if err != nil {
+		return err
+	}
+	if nextMilestone != "true" {
+		return nil
+	}
+	nextVersion, err := repository.NextVersion()
+	if err != nil {
+		return err
+	}
+	milestone, err = GetMilestoneByTitle(ctx, client, org, repo, nextVersion.String())
+	if err != nil {
+		return err
+	}
+	if milestone == nil {
+		_, err = CreateMilestone(ctx, client, org, repo, nextVersion.String())
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created milestone", nextVersion)
+	}
+
+	return nil
+}
+
+// YankOptions ...
+type YankOptions struct {
+	// Reason explains why the release is yanked
+	Reason string
+	// DeleteTag deletes the release tag locally and on origin
+	DeleteTag bool
+	// Yes skips the tag deletion confirmation
+	Yes bool
+}
+
+// ReleaseYank retracts a published release. Its GitHub release is marked
+// as pre-release with a warning, a retract directive is added to go.mod when
+// the release has a vX.Y.Z module version tag and the yank is recorded on
+// CHANGELOG.md.
+func ReleaseYank(path string, gitHubToken string, versionName string, options *YankOptions) {
+	// Open repository
+	repository, err := OpenRepository(path, gitHubToken)
+	if err != nil {
+		fmt.Println(color.RedString("ERROR: %s", err.Error()))
+		os.Exit(1)
+	}
+
+	// Parse the version and check its tag
+	var version Version
+	if repository.CalVerScheme != "" {
+		version, err = NewCalVer(repository.CalVerScheme, versionName)
+	} else {
+		version, err = NewSemVer(strings.TrimPrefix(versionName, "v"))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	tagName := version.String()
+	tags, err := repository.GetTags()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !containsString(tags, tagName) {
+		log.Fatalf("There is no tag for version %q", tagName)
+	}
+	fmt.Println("Yanking release", tagName)
+
+	// Mark the GitHub release
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, repository.GitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	release, err := YankGitHubRelease(ctx, client, org, repo, tagName, options.Reason)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if release != nil {
+		fmt.Println("Marked GitHub release as pre-release", release.GetHTMLURL())
+	} else {
+		fmt.Println("There is no GitHub release for tag", tagName)
+	}
+
+	// Retract the version on go.mod, Go modules only see vX.Y.Z tags
+	changedFiles := []string{}
+	if IsGoModule(path) {
+		moduleVersion := "v" + tagName
+		if _, ok := version.(*SemVer); !ok {
+			fmt.Println(color.YellowString("WARNING: Go modules can only retract SemVer versions"))
+		} else if !containsString(tags, moduleVersion) {
+			fmt.Println(color.YellowString("WARNING: There is no %s tag, %s is not a Go module version to retract", moduleVersion, tagName))
+		} else {
+			err = AddGoModuleRetract(path, moduleVersion, options.Reason)
+			if err != nil {
+				log.Fatal(err)
+			}
+			changedFiles = append(changedFiles, filepath.Join(path, "go.mod"))
+			fmt.Println("Added retract directive to go.mod")
+		}
+	}
+
+	// Record the yank on the changelog
+	err = YankChangelog(path, tagName, options.Reason)
+	if err != nil {
+		log.Fatal(err)
+	}
+	changedFiles = append(changedFiles, filepath.Join(path, "CHANGELOG.md"))
+	fmt.Println("Recorded yank on CHANGELOG.md")
+
+	// Commit and push the changes
+	out, err := automation.CommitFiles(fmt.Sprintf("Yank %s", tagName), changedFiles...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(out)
+	out, err = automation.GitPush()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(out)
+
+	// Delete the tag
+	if !options.DeleteTag {
+		return
+	}
+	if !options.Yes {
+		confirmed, err := Confirm(fmt.Sprintf("Delete tag %s locally and on origin?", tagName))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !confirmed {
+			return
+		}
+	}
+	out, err = automation.DeleteRemoteTag(tagName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Deleting remote tag", tagName)
+	fmt.Println(out)
+	out, err = automation.DeleteLocalTag(tagName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Deleting local tag", tagName)
+	fmt.Println(out)
+}
+
+// YankGitHubRelease marks the GitHub release of a tag as pre-release and
+// prepends a warning to its body. It returns nil if there is no release for
+// the tag.
+func YankGitHubRelease(ctx context.Context, client *github.Client, organization string, repository string, tagName string, reason string) (*github.RepositoryRelease, error) {
+	release, response, err := client.Repositories.GetReleaseByTag(ctx, organization, repository, tagName)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	prerelease := true
+	body := fmt.Sprintf("> **WARNING**: This release has been yanked: %s\n\n%s", reason, release.GetBody())
+	release, _, err = client.Repositories.EditRelease(ctx, organization, repository, release.GetID(), &github.RepositoryRelease{
+		Prerelease: &prerelease,
+		Body:       &body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
 }
@@ -23,12 +23,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/github"
-	"golang.org/x/oauth2"
+	"github.com/repejota/git-hub/automation"
 	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 )
 
 // Repository ...
@@ -37,6 +40,17 @@ type Repository struct {
 	GitHubToken      string
 	GitRepository    *git.Repository
 	GitHubRepository *github.Repository
+	// CalVerScheme is the calendar versioning scheme, empty for SemVer
+	CalVerScheme string
+}
+
+// RepositoryInfo is the repository information shown by the info command
+type RepositoryInfo struct {
+	Path          string `json:"path" yaml:"path"`
+	ID            int64  `json:"id" yaml:"id"`
+	FullName      string `json:"full_name" yaml:"full_name"`
+	HTMLURL       string `json:"html_url" yaml:"html_url"`
+	DefaultBranch string `json:"default_branch" yaml:"default_branch"`
 }
 
 // OpenRepository opens a repository from a path
@@ -55,20 +69,40 @@ func OpenRepository(path string, gitHubToken string) (*Repository, error) {
 		return nil, err
 	}
 
+	calVerScheme, err := automation.GetConfig("calver")
+	if err != nil {
+		return nil, err
+	}
+	repository.CalVerScheme = calVerScheme
+
 	log.Printf("Opened repository at %q", path)
 
 	return repository, nil
 }
 
+// Info ...
+func (r *Repository) Info() *RepositoryInfo {
+	info := &RepositoryInfo{
+		Path:          r.Path,
+		ID:            r.GitHubRepository.GetID(),
+		FullName:      r.GitHubRepository.GetFullName(),
+		HTMLURL:       r.GitHubRepository.GetHTMLURL(),
+		DefaultBranch: r.GitHubRepository.GetDefaultBranch(),
+	}
+	return info
+}
+
 // GetNewIssueURL ...
 func (r *Repository) GetNewIssueURL(repositoryFullName string) string {
 	url := fmt.Sprintf("https://github.com/%s/issues/new", repositoryFullName)
 	return url
 }
 
-// Git ...
+// Git opens the git repository at path. Linked working trees are opened at
+// the repository they belong to, as go-git does not follow their commondir
+// file and would miss the branches, tags and remotes.
 func (r *Repository) Git(path string) error {
-	gitRepository, err := git.PlainOpen(path)
+	gitRepository, err := git.PlainOpen(mainRepositoryPath(path))
 	if err != nil {
 		return err
 	}
@@ -76,6 +110,32 @@ func (r *Repository) Git(path string) error {
 	return nil
 }
 
+// mainRepositoryPath returns the path of the main working tree, or of the
+// bare repository, the linked working tree at path belongs to, or path
+// itself if it is not a linked working tree
+func mainRepositoryPath(path string) string {
+	data, err := ioutil.ReadFile(filepath.Join(path, ".git"))
+	if err != nil {
+		return path
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	data, err = ioutil.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return path
+	}
+	commonDir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir)
+	}
+	return commonDir
+}
+
 // GetRemoteGithubRepository ...
 func (r *Repository) GetRemoteGithubRepository(remoteName string) error {
 	// Get remote
@@ -96,13 +156,7 @@ func (r *Repository) GetRemoteGithubRepository(remoteName string) error {
 	if r.GitHubToken == "" {
 		return fmt.Errorf("A valid GitHub Token is required")
 	}
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: r.GitHubToken,
-		},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+	client := NewGitHubClient(ctx, r.GitHubToken)
 	githubRepository, _, err := client.Repositories.Get(ctx, organization, repository)
 	if err != nil {
 		return err
@@ -112,12 +166,27 @@ func (r *Repository) GetRemoteGithubRepository(remoteName string) error {
 }
 
 // GetCurrentVersion ...
-func (r *Repository) GetCurrentVersion() (*SemVer, error) {
-	data, err := ioutil.ReadFile("VERSION")
+func (r *Repository) GetCurrentVersion() (Version, error) {
+	if r.CalVerScheme == "" {
+		return r.GetCurrentSemVer()
+	}
+	sdata, err := readVersionFile()
+	if err != nil {
+		return nil, err
+	}
+	version, err := NewCalVer(r.CalVerScheme, sdata)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// GetCurrentSemVer ...
+func (r *Repository) GetCurrentSemVer() (*SemVer, error) {
+	sdata, err := readVersionFile()
 	if err != nil {
 		return nil, err
 	}
-	sdata := strings.Trim(string(data), "\n")
 	version, err := NewSemVer(sdata)
 	if err != nil {
 		return nil, err
@@ -126,8 +195,16 @@ func (r *Repository) GetCurrentVersion() (*SemVer, error) {
 }
 
 // NextVersion ...
-func (r *Repository) NextVersion() (*SemVer, error) {
-	version, err := r.GetCurrentVersion()
+func (r *Repository) NextVersion() (Version, error) {
+	if r.CalVerScheme != "" {
+		tags, err := r.GetTags()
+		if err != nil {
+			return nil, err
+		}
+		return NextCalVer(r.CalVerScheme, time.Now(), tags)
+	}
+
+	version, err := r.GetCurrentSemVer()
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +214,81 @@ func (r *Repository) NextVersion() (*SemVer, error) {
 	return version, nil
 }
 
+// ParseVersion parses a version following the repository scheme, CalVer if
+// configured or SemVer otherwise
+func (r *Repository) ParseVersion(version string) (Version, error) {
+	if r.CalVerScheme != "" {
+		return NewCalVer(r.CalVerScheme, version)
+	}
+	return NewSemVer(version)
+}
+
+// CandidateVersions returns the versions the next release can have, the
+// next patch, minor and major versions for SemVer or the next version for
+// CalVer
+func (r *Repository) CandidateVersions() ([]Version, error) {
+	if r.CalVerScheme != "" {
+		version, err := r.NextVersion()
+		if err != nil {
+			return nil, err
+		}
+		return []Version{version}, nil
+	}
+
+	version, err := r.GetCurrentSemVer()
+	if err != nil {
+		return nil, err
+	}
+	patch := &SemVer{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1}
+	minor := &SemVer{Major: version.Major, Minor: version.Minor + 1}
+	major := &SemVer{Major: version.Major}
+	major.BumpMajor()
+	return []Version{patch, minor, major}, nil
+}
+
+// NextMajorVersion ...
+func (r *Repository) NextMajorVersion() (*SemVer, error) {
+	if r.CalVerScheme != "" {
+		return nil, fmt.Errorf("Major releases are not supported with CalVer scheme %q", r.CalVerScheme)
+	}
+
+	version, err := r.GetCurrentSemVer()
+	if err != nil {
+		return nil, err
+	}
+
+	version.BumpMajor()
+
+	return version, nil
+}
+
+// GetTags returns the names of the repository tags
+func (r *Repository) GetTags() ([]string, error) {
+	tagRefs, err := r.GitRepository.Tags()
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		tags = append(tags, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// readVersionFile returns the contents of the VERSION file
+func readVersionFile() (string, error) {
+	data, err := ioutil.ReadFile("VERSION")
+	if err != nil {
+		return "", err
+	}
+	sdata := strings.Trim(string(data), "\n")
+	return sdata, nil
+}
+
 // ParseGithubURL ...
 func ParseGithubURL(url string) (string, string, string, error) {
 	// git@github.com:repejota/git-hub.git
@@ -23,6 +23,11 @@ import (
 	"strings"
 )
 
+// Version is a release version, a SemVer or a CalVer
+type Version interface {
+	String() string
+}
+
 // SemVer ...
 type SemVer struct {
 	Major int
@@ -34,7 +39,7 @@ type SemVer struct {
 func NewSemVer(version string) (*SemVer, error) {
 	semver := &SemVer{}
 	dataParts := strings.Split(string(version), ".")
-	if len(dataParts) < 2 {
+	if len(dataParts) < 3 {
 		return nil, fmt.Errorf("ERROR invalid VERSION format: %s", string(version))
 	}
 	part, err := strconv.Atoi(dataParts[0])
@@ -59,3 +64,10 @@ func (v *SemVer) String() string {
 	strSemVer := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 	return strSemVer
 }
+
+// BumpMajor increments the major version and resets minor and patch
+func (v *SemVer) BumpMajor() {
+	v.Major = v.Major + 1
+	v.Minor = 0
+	v.Patch = 0
+}
@@ -58,6 +58,20 @@ func TestInvalidVersionFormat(t *testing.T) {
 	}
 }
 
+func TestMissingPatchVersion(t *testing.T) {
+	version := "1.2"
+	expectedError := fmt.Sprintf("ERROR invalid VERSION format: %s", version)
+
+	_, err := ghub.NewSemVer(version)
+	if err == nil {
+		t.Fatal("Expected an error for a version without patch")
+	}
+
+	if err.Error() != expectedError {
+		t.Fatalf("Invalid error, expected %q but got %q", expectedError, err.Error())
+	}
+}
+
 func TestInvalidMajorVersion(t *testing.T) {
 	version := "a.2.3"
 	expectedError := fmt.Sprintf("ERROR invalid Major version: %s", version)
@@ -99,3 +113,17 @@ func TestInvalidPatchVersion(t *testing.T) {
 		t.Fatalf("Invalid error, expected %q but got %q", expectedError, err.Error())
 	}
 }
+
+func TestSemVerBumpMajor(t *testing.T) {
+	expectedVersion := "2.0.0"
+
+	version, err := ghub.NewSemVer("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	version.BumpMajor()
+
+	if version.String() != expectedVersion {
+		t.Fatalf("Version expected to be %q but got %q", expectedVersion, version.String())
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub/automation"
+)
+
+// Markers of the stack list on the pull request bodies
+const (
+	stackSectionStart = "<!-- git-hub stack -->"
+	stackSectionEnd   = "<!-- /git-hub stack -->"
+)
+
+// StackBranches returns the branches of the stack a branch belongs to, from
+// the bottom of the stack up, so parents always come before their children.
+// The branch the stack starts from, usually the default one, is not part of
+// it. Parents map stacked branches to their parent.
+func StackBranches(parents map[string]string, branchName string) []string {
+	// Go down to the bottom of the stack
+	bottom := ""
+	seen := map[string]bool{}
+	for !seen[branchName] {
+		seen[branchName] = true
+		parent, ok := parents[branchName]
+		if !ok {
+			break
+		}
+		bottom = branchName
+		branchName = parent
+	}
+	if bottom == "" {
+		return nil
+	}
+
+	children := map[string][]string{}
+	for child, parent := range parents {
+		children[parent] = append(children[parent], child)
+	}
+	branches := []string{}
+	walked := map[string]bool{}
+	var walk func(branchName string)
+	walk = func(branchName string) {
+		if walked[branchName] {
+			return
+		}
+		walked[branchName] = true
+		branches = append(branches, branchName)
+		sort.Strings(children[branchName])
+		for _, child := range children[branchName] {
+			walk(child)
+		}
+	}
+	walk(bottom)
+	return branches
+}
+
+// StackSection returns the list of the pull requests of a stack pointing at
+// the one at current
+func StackSection(pullRequests []*github.PullRequest, current int) string {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, stackSectionStart)
+	fmt.Fprintln(&buf, "**Stack**")
+	fmt.Fprintln(&buf)
+	for i, pullRequest := range pullRequests {
+		line := fmt.Sprintf("- #%d %s", pullRequest.GetNumber(), pullRequest.GetTitle())
+		if i == current {
+			line = fmt.Sprintf("- **#%d %s** 👈", pullRequest.GetNumber(), pullRequest.GetTitle())
+		}
+		fmt.Fprintln(&buf, line)
+	}
+	fmt.Fprint(&buf, stackSectionEnd)
+	return buf.String()
+}
+
+// SetStackSection replaces the stack list of a pull request body, or appends
+// it if there is none
+func SetStackSection(body string, section string) string {
+	start := strings.Index(body, stackSectionStart)
+	end := strings.Index(body, stackSectionEnd)
+	if start != -1 && end > start {
+		return body[:start] + section + body[end+len(stackSectionEnd):]
+	}
+	if strings.TrimSpace(body) == "" {
+		return section
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + section
+}
+
+// StackSync rebases the stack of the current branch, each branch onto its
+// parent after the default branch is fast forwarded. The branches whose
+// pull request is merged are skipped and their children moved onto the
+// closest parent not merged. It stops on the first conflict, aborting it, or
+// on any other failure, and the branch and uncommitted changes there were
+// are restored at the end.
+func StackSync(repositoryPath string, gitHubToken string) (results []*SyncResult, err error) {
+	// Open repository
+	repository, err := OpenRepository(repositoryPath, gitHubToken)
+	if err != nil {
+		return nil, err
+	}
+	defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+
+	branches, parents, err := currentStack()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the current branch and changes to restore them
+	restore, err := keepWorkingState("git-hub stack sync")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		restoreErr := restore()
+		if restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	// Fast forward the default branch
+	log.Println("Updating", defaultBranchName)
+	_, err = automation.FetchOrigin()
+	if err != nil {
+		return nil, err
+	}
+	_, err = automation.FastForwardBranch(defaultBranchName)
+	if err != nil {
+		return nil, fmt.Errorf("Can not fast forward %s: %s", defaultBranchName, err)
+	}
+
+	// Merged branches
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, gitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	merged, err := mergedBranches(ctx, client, org, repo, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tips of the branches before rebasing them, their commits are the
+	// ones not to move with their children
+	tips := map[string]string{}
+	for _, branchName := range branches {
+		tip, err := automation.GetRevision(branchName)
+		if err != nil {
+			return nil, err
+		}
+		tips[branchName] = tip
+	}
+
+	conflicted := false
+	for _, branchName := range branches {
+		result := &SyncResult{Branch: branchName}
+		results = append(results, result)
+		if conflicted {
+			result.Result = SyncSkipped
+			result.Reason = "after a conflict"
+			continue
+		}
+		if merged[branchName] {
+			result.Result = SyncSkipped
+			result.Reason = "pull request merged"
+			continue
+		}
+
+		// Onto the closest parent not merged, or the default branch if the
+		// parent is gone
+		parent := parents[branchName]
+		upstream := parent
+		if tip, ok := tips[parent]; ok {
+			upstream = tip
+		}
+		onto := parent
+		for merged[onto] {
+			onto = parents[onto]
+		}
+		if _, err := automation.GetRevision(onto); err != nil {
+			onto = defaultBranchName
+			upstream = defaultBranchName
+		}
+
+		_, behind, err := automation.GetAheadBehind(onto, branchName)
+		if err != nil {
+			return nil, err
+		}
+		if behind == 0 && onto == parent {
+			result.Result = SyncUpToDate
+			continue
+		}
+
+		log.Println("Rebasing", branchName, "onto", onto)
+		_, err = automation.RebaseBranchOnto(branchName, onto, upstream)
+		if err != nil {
+			conflicted, err = abortConflicts(err, automation.AbortRebase, "Can not rebase %s onto %s", branchName, onto)
+			if err != nil {
+				result.Result = SyncFailed
+				result.Reason = err.Error()
+				return results, err
+			}
+			result.Result = SyncConflicted
+			result.Reason = fmt.Sprintf("rebase onto %s aborted", onto)
+			continue
+		}
+		result.Result = SyncUpdated
+		result.Reason = fmt.Sprintf("rebased onto %s", onto)
+		if onto != parent {
+			err = automation.SetBranchParent(branchName, onto)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// StackSubmit pushes the branches of the stack of the current branch and
+// opens or updates their pull requests, each one based on the branch of its
+// closest parent not merged and with the list of the pull requests of the
+// stack on its body. Branches whose pull request is merged are skipped.
+func StackSubmit(repositoryPath string, gitHubToken string) ([]*github.PullRequest, error) {
+	// Open repository
+	repository, err := OpenRepository(repositoryPath, gitHubToken)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, parents, err := currentStack()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, gitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	merged, err := mergedBranches(ctx, client, org, repo, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests := []*github.PullRequest{}
+	for _, branchName := range branches {
+		// Merged branches have no pull request to open, their children are
+		// based on the closest parent not merged
+		if merged[branchName] {
+			log.Println("Skipping merged", branchName)
+			continue
+		}
+		baseBranchName := parents[branchName]
+		for merged[baseBranchName] {
+			baseBranchName = parents[baseBranchName]
+		}
+
+		// Stacks are rebased, so branches are force pushed
+		log.Println("Pushing", branchName)
+		_, err := automation.ForcePushBranch(branchName)
+		if err != nil {
+			return nil, err
+		}
+
+		pullRequest, err := FindOpenPullRequest(ctx, client, org, repo, branchName)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case pullRequest == nil:
+			subjects, err := automation.GetCommitSubjects(baseBranchName, branchName)
+			if err != nil {
+				return nil, err
+			}
+			title := PullRequestTitle(branchName, subjects)
+			body := PullRequestBody(subjects)
+			pullRequest, err = CreatePullRequest(ctx, client, org, repo, &github.NewPullRequest{
+				Title: &title,
+				Head:  &branchName,
+				Base:  &baseBranchName,
+				Body:  &body,
+			})
+			if err != nil {
+				return nil, err
+			}
+			log.Printf("Created pull request #%d\n", pullRequest.GetNumber())
+		case pullRequest.GetBase().GetRef() != baseBranchName:
+			pullRequest, _, err = client.PullRequests.Edit(ctx, org, repo, pullRequest.GetNumber(), &github.PullRequest{
+				Base: &github.PullRequestBranch{Ref: &baseBranchName},
+			})
+			if err != nil {
+				return nil, err
+			}
+			log.Printf("Changed the base of pull request #%d to %s\n", pullRequest.GetNumber(), baseBranchName)
+		}
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	// The stack list needs all the pull requests
+	for i, pullRequest := range pullRequests {
+		body := SetStackSection(pullRequest.GetBody(), StackSection(pullRequests, i))
+		if body == pullRequest.GetBody() {
+			continue
+		}
+		pullRequest, _, err = client.PullRequests.Edit(ctx, org, repo, pullRequest.GetNumber(), &github.PullRequest{Body: &body})
+		if err != nil {
+			return nil, err
+		}
+		pullRequests[i] = pullRequest
+	}
+	return pullRequests, nil
+}
+
+// mergedBranches returns which branches have their latest pull request
+// merged
+func mergedBranches(ctx context.Context, client *github.Client, organization string, repository string, branches []string) (map[string]bool, error) {
+	reports := []*BranchReport{}
+	for _, branchName := range branches {
+		reports = append(reports, &BranchReport{Name: branchName})
+	}
+	err := AddBranchStates(ctx, client, organization, repository, reports)
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]bool{}
+	for _, report := range reports {
+		merged[report.Name] = report.PullRequestState == PullRequestStateMerged
+	}
+	return merged, nil
+}
+
+// currentStack returns the branches of the stack of the current branch and
+// the parents of the stacked branches
+func currentStack() ([]string, map[string]string, error) {
+	currentBranchName, err := automation.GetCurrentBranch()
+	if err != nil {
+		return nil, nil, err
+	}
+	parents, err := automation.GetBranchParents()
+	if err != nil {
+		return nil, nil, err
+	}
+	branches := StackBranches(parents, currentBranchName)
+	if len(branches) == 0 {
+		return nil, nil, fmt.Errorf("Branch %q is not stacked", currentBranchName)
+	}
+	return branches, parents, nil
+}
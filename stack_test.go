@@ -0,0 +1,81 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub"
+)
+
+func TestStackBranches(t *testing.T) {
+	parents := map[string]string{
+		"issue/11-base":   "master",
+		"issue/12-middle": "issue/11-base",
+		"issue/14-other":  "issue/11-base",
+		"issue/13-top":    "issue/12-middle",
+		"feature/alone":   "master",
+		"issue/20-a":      "issue/21-b",
+		"issue/21-b":      "issue/20-a",
+	}
+	tests := []struct {
+		branchName string
+		expected   []string
+	}{
+		{"issue/13-top", []string{"issue/11-base", "issue/12-middle", "issue/13-top", "issue/14-other"}},
+		{"issue/11-base", []string{"issue/11-base", "issue/12-middle", "issue/13-top", "issue/14-other"}},
+		{"feature/alone", []string{"feature/alone"}},
+		{"master", nil},
+		{"issue/20-a", []string{"issue/21-b", "issue/20-a"}},
+	}
+	for _, test := range tests {
+		branches := ghub.StackBranches(parents, test.branchName)
+		if !reflect.DeepEqual(branches, test.expected) {
+			t.Fatalf("Expected stack %q for %s but got %q", test.expected, test.branchName, branches)
+		}
+	}
+}
+
+func TestSetStackSection(t *testing.T) {
+	pullRequests := []*github.PullRequest{
+		{Number: github.Int(11), Title: github.String("Base")},
+		{Number: github.Int(12), Title: github.String("Middle")},
+	}
+	section := ghub.StackSection(pullRequests, 1)
+	expectedSection := "<!-- git-hub stack -->\n**Stack**\n\n- #11 Base\n- **#12 Middle** 👈\n<!-- /git-hub stack -->"
+	if section != expectedSection {
+		t.Fatalf("Expected section %q but got %q", expectedSection, section)
+	}
+
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{"", section},
+		{"Closes #12\n", "Closes #12\n\n" + section},
+		{"Closes #12\n\n<!-- git-hub stack -->\nold\n<!-- /git-hub stack -->\n\nMore", "Closes #12\n\n" + section + "\n\nMore"},
+	}
+	for _, test := range tests {
+		body := ghub.SetStackSection(test.body, section)
+		if body != test.expected {
+			t.Fatalf("Expected body %q but got %q", test.expected, body)
+		}
+	}
+}
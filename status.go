@@ -0,0 +1,130 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub/automation"
+)
+
+// Review states
+const (
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes requested"
+	ReviewRequired         = "review required"
+)
+
+// BranchStatus is the status of a branch shown by the status command
+type BranchStatus struct {
+	Branch      string `json:"branch" yaml:"branch"`
+	Kind        string `json:"kind" yaml:"kind"`
+	Issue       string `json:"issue" yaml:"issue"`
+	PullRequest string `json:"pull_request" yaml:"pull_request"`
+	Checks      string `json:"checks" yaml:"checks"`
+	Review      string `json:"review" yaml:"review"`
+	Ahead       int    `json:"ahead" yaml:"ahead"`
+	Behind      int    `json:"behind" yaml:"behind"`
+	Changes     int    `json:"changes" yaml:"changes"`
+}
+
+// Review returns the review state of a pull request, approved, changes
+// requested or review required
+func (s *PullRequestStatus) Review() string {
+	switch {
+	case s.ChangesRequested:
+		return ReviewChangesRequested
+	case s.Approvals > 0:
+		return ReviewApproved
+	}
+	return ReviewRequired
+}
+
+// GetBranchStatus returns the status of a branch, its linked issue and open
+// pull request, checks and review state, commits ahead and behind of the
+// default branch and the uncommitted changes of the working tree.
+// issueRepositoryFullName is only given for issue branches of another
+// repository.
+func GetBranchStatus(ctx context.Context, client *github.Client, repository *Repository, branchName string, issueRepositoryFullName string) (*BranchStatus, error) {
+	defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+	branchContext := ParseBranch(branchName, defaultBranchName)
+	status := &BranchStatus{
+		Branch: branchName,
+		Kind:   branchContext.Kind,
+	}
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+
+	// Linked issue
+	if branchContext.Issue != nil {
+		issueRepository := repository.GitHubRepository.GetFullName()
+		if branchContext.Issue.RepositorySlug != "" {
+			if issueRepositoryFullName == "" || SlugifyRepository(issueRepositoryFullName) != branchContext.Issue.RepositorySlug {
+				return nil, fmt.Errorf("Branch %q belongs to an issue of another repository", branchName)
+			}
+			issueRepository = issueRepositoryFullName
+		}
+		issueOrg, issueRepo := ParseRepositoryFullName(issueRepository)
+		issue, err := GetIssue(ctx, client, issueOrg, issueRepo, branchContext.Issue.Number)
+		if err != nil {
+			return nil, err
+		}
+		status.Issue = fmt.Sprintf("#%d %s (%s)", issue.GetNumber(), issue.GetTitle(), issue.GetState())
+	}
+
+	// Open pull request, its checks and reviews
+	pullRequest, err := FindOpenPullRequest(ctx, client, org, repo, branchName)
+	if err != nil {
+		return nil, err
+	}
+	if pullRequest != nil {
+		pullRequestStatus, err := GetPullRequestStatus(ctx, client, org, repo, pullRequest)
+		if err != nil {
+			return nil, err
+		}
+		status.PullRequest = fmt.Sprintf("#%d %s", pullRequest.GetNumber(), pullRequest.GetHTMLURL())
+		status.Checks = pullRequestStatus.Checks
+		status.Review = pullRequestStatus.Review()
+	} else {
+		// Branches that are not pushed have no checks
+		checks, err := GetChecksState(ctx, client, org, repo, branchName)
+		if err != nil {
+			log.Printf("Can't get the checks of %q: %s", branchName, err)
+		}
+		status.Checks = checks
+	}
+
+	// Commits ahead and behind of the default branch
+	ahead, behind, err := automation.GetAheadBehind("origin/"+defaultBranchName, branchName)
+	if err != nil {
+		return nil, err
+	}
+	status.Ahead = ahead
+	status.Behind = behind
+
+	// Uncommitted changes
+	changes, err := automation.GetUncommittedChanges()
+	if err != nil {
+		return nil, err
+	}
+	status.Changes = len(changes)
+
+	return status, nil
+}
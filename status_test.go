@@ -0,0 +1,41 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestPullRequestStatusReview(t *testing.T) {
+	tests := []struct {
+		status   *ghub.PullRequestStatus
+		expected string
+	}{
+		{&ghub.PullRequestStatus{}, ghub.ReviewRequired},
+		{&ghub.PullRequestStatus{Approvals: 2}, ghub.ReviewApproved},
+		{&ghub.PullRequestStatus{Approvals: 1, ChangesRequested: true}, ghub.ReviewChangesRequested},
+	}
+	for _, test := range tests {
+		review := test.status.Review()
+		if review != test.expected {
+			t.Fatalf("Expected review %q but got %q", test.expected, review)
+		}
+	}
+}
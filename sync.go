@@ -0,0 +1,229 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/repejota/git-hub/automation"
+)
+
+// Sync strategies
+const (
+	SyncMerge  = "merge"
+	SyncRebase = "rebase"
+)
+
+// Sync results
+const (
+	SyncUpdated    = "updated"
+	SyncUpToDate   = "up to date"
+	SyncConflicted = "conflicted"
+	SyncFailed     = "failed"
+	SyncSkipped    = "skipped"
+)
+
+// SyncOptions ...
+type SyncOptions struct {
+	// Strategy is how the default branch gets into the workflow branches,
+	// merge or rebase. It defaults to the git-hub.syncStrategy setting or
+	// merge.
+	Strategy string
+}
+
+// SyncResult is the outcome of syncing a workflow branch
+type SyncResult struct {
+	Branch string `json:"branch" yaml:"branch"`
+	Result string `json:"result" yaml:"result"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// Highlighted highlights conflicted and failed branches
+func (s *SyncResult) Highlighted() bool {
+	return s.Result == SyncConflicted || s.Result == SyncFailed
+}
+
+// Sync fast forwards the local default branch and merges it into, or
+// rebases onto it, the local feature and issue branches that are not
+// merged yet. It stops on the first conflict, aborting it, or on any other
+// failure, and the branch and uncommitted changes there were are restored at
+// the end.
+func Sync(repositoryPath string, gitHubToken string, options *SyncOptions) (results []*SyncResult, err error) {
+	// Open repository
+	repository, err := OpenRepository(repositoryPath, gitHubToken)
+	if err != nil {
+		return nil, err
+	}
+	defaultBranchName := repository.GitHubRepository.GetDefaultBranch()
+
+	strategy, err := SyncStrategy(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the current branch and changes to restore them
+	restore, err := keepWorkingState("git-hub sync")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		restoreErr := restore()
+		if restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+	}()
+
+	// Fast forward the default branch
+	log.Println("Updating", defaultBranchName)
+	_, err = automation.FetchOrigin()
+	if err != nil {
+		return nil, err
+	}
+	_, err = automation.FastForwardBranch(defaultBranchName)
+	if err != nil {
+		return nil, fmt.Errorf("Can not fast forward %s: %s", defaultBranchName, err)
+	}
+
+	reports, err := repository.ListWorkflowBranches("feature/", "issue/")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	client := NewGitHubClient(ctx, gitHubToken)
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	err = AddBranchStates(ctx, client, org, repo, reports)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicted := false
+	for _, report := range reports {
+		if !report.Local {
+			continue
+		}
+		result := &SyncResult{Branch: report.Name}
+		results = append(results, result)
+		reason := report.PruneReason(0, time.Now())
+		switch {
+		case conflicted:
+			result.Result = SyncSkipped
+			result.Reason = "after a conflict"
+		case reason != "":
+			result.Result = SyncSkipped
+			result.Reason = reason
+		case report.Behind == 0:
+			result.Result = SyncUpToDate
+		default:
+			log.Println("Syncing", report.Name)
+			conflicted, err = syncBranch(report.Name, defaultBranchName, strategy)
+			if err != nil {
+				result.Result = SyncFailed
+				result.Reason = err.Error()
+				return results, err
+			}
+			if conflicted {
+				result.Result = SyncConflicted
+				result.Reason = fmt.Sprintf("%s aborted", strategy)
+				continue
+			}
+			result.Result = SyncUpdated
+			result.Reason = fmt.Sprintf("%d commits behind", report.Behind)
+		}
+	}
+	return results, nil
+}
+
+// keepWorkingState stashes the uncommitted changes, and returns a function
+// that goes back to the current branch and pops them
+func keepWorkingState(message string) (func() error, error) {
+	currentBranchName, err := automation.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	stashed, err := automation.StashChanges(message)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		log.Println("Going back to", currentBranchName)
+		_, err := automation.GoGitBranch(currentBranchName)
+		if err != nil || !stashed {
+			return err
+		}
+		_, err = automation.PopStash()
+		return err
+	}, nil
+}
+
+// syncBranch merges a base branch into a branch, or rebases the branch onto
+// it. It returns true if it was aborted on conflicts.
+func syncBranch(branchName string, baseBranchName string, strategy string) (bool, error) {
+	if strategy == SyncRebase {
+		_, err := automation.RebaseBranch(branchName, baseBranchName)
+		if err != nil {
+			return abortConflicts(err, automation.AbortRebase, "Can not rebase %s onto %s", branchName, baseBranchName)
+		}
+		return false, nil
+	}
+	_, err := automation.GoGitBranch(branchName)
+	if err != nil {
+		return false, err
+	}
+	_, err = automation.MergeRevision(baseBranchName)
+	if err != nil {
+		return abortConflicts(err, automation.AbortMerge, "Can not merge %s into %s", baseBranchName, branchName)
+	}
+	return false, nil
+}
+
+// abortConflicts aborts a failed merge or rebase, and returns true if it
+// failed on conflicts or the error described otherwise
+func abortConflicts(err error, abort func() error, format string, args ...interface{}) (bool, error) {
+	conflicted, conflictsErr := automation.HasConflicts()
+	abort()
+	if conflictsErr != nil {
+		return false, conflictsErr
+	}
+	if !conflicted {
+		return false, fmt.Errorf("%s: %s", fmt.Sprintf(format, args...), err)
+	}
+	return true, nil
+}
+
+// SyncStrategy returns the strategy of the options, the configured one or
+// merge.
+func SyncStrategy(options *SyncOptions) (string, error) {
+	strategy := options.Strategy
+	if strategy == "" {
+		configured, err := automation.GetConfig("syncStrategy")
+		if err != nil {
+			return "", err
+		}
+		strategy = configured
+	}
+	switch strategy {
+	case "":
+		return SyncMerge, nil
+	case SyncMerge, SyncRebase:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("Invalid sync strategy %q", strategy)
+}
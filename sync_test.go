@@ -0,0 +1,68 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub_test
+
+import (
+	"testing"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestSyncStrategy(t *testing.T) {
+	tests := []struct {
+		strategy   string
+		configured string
+		expected   string
+	}{
+		{"", "", ghub.SyncMerge},
+		{"", "rebase", ghub.SyncRebase},
+		{"", "merge", ghub.SyncMerge},
+		{"merge", "rebase", ghub.SyncMerge},
+		{"rebase", "", ghub.SyncRebase},
+		{"rebase", "squash", ghub.SyncRebase},
+	}
+	for _, test := range tests {
+		inGitRepository(t, "syncStrategy", test.configured, func() {
+			strategy, err := ghub.SyncStrategy(&ghub.SyncOptions{Strategy: test.strategy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strategy != test.expected {
+				t.Fatalf("Expected strategy %q for %q configured as %q but got %q", test.expected, test.strategy, test.configured, strategy)
+			}
+		})
+	}
+}
+
+func TestSyncStrategyInvalid(t *testing.T) {
+	tests := []struct {
+		strategy   string
+		configured string
+	}{
+		{"squash", ""},
+		{"", "squash"},
+	}
+	for _, test := range tests {
+		inGitRepository(t, "syncStrategy", test.configured, func() {
+			_, err := ghub.SyncStrategy(&ghub.SyncOptions{Strategy: test.strategy})
+			if err == nil {
+				t.Fatalf("Expected an error for %q configured as %q", test.strategy, test.configured)
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@
 package ghub
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Slugify ...
@@ -28,3 +31,70 @@ func Slugify(str string) string {
 	slugifyString := strings.Trim(re.ReplaceAllString(strings.ToLower(str), "-"), "-")
 	return slugifyString
 }
+
+// containsString returns true if a string is on a list
+func containsString(list []string, str string) bool {
+	for _, item := range list {
+		if item == str {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAge parses a duration that also accepts days and weeks, like 90d or
+// 2w, besides the time.ParseDuration units.
+func ParseAge(age string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if !strings.HasSuffix(age, suffix) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSuffix(age, suffix))
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q", age)
+		}
+		return time.Duration(count) * unit, nil
+	}
+	return time.ParseDuration(age)
+}
+
+// ParseSince parses a point in time given as a date, a RFC3339 timestamp or
+// an age relative to now like 7d.
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", since); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, since); err == nil {
+		return date, nil
+	}
+	age, err := ParseAge(since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid date %q", since)
+	}
+	return now.Add(-age), nil
+}
+
+// HumanizeTime returns how long ago a time was, like "3 days ago"
+func HumanizeTime(t time.Time, now time.Time) string {
+	elapsed := now.Sub(t)
+	units := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"month", 30 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	for _, unit := range units {
+		count := int(elapsed / unit.duration)
+		if count == 1 {
+			return fmt.Sprintf("1 %s ago", unit.name)
+		}
+		if count > 1 {
+			return fmt.Sprintf("%d %ss ago", count, unit.name)
+		}
+	}
+	return "just now"
+}
@@ -16,3 +16,67 @@
 // under the License.
 
 package ghub_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/repejota/git-hub"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age      string
+		expected time.Duration
+	}{
+		{"90d", 90 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+	}
+	for _, test := range tests {
+		age, err := ghub.ParseAge(test.age)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if age != test.expected {
+			t.Fatalf("Expected age %s but got %s", test.expected, age)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2018, time.October, 19, 0, 0, 0, 0, time.UTC)
+	expected := time.Date(2018, time.October, 12, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2018-10-12", "2018-10-12T00:00:00Z", "7d"} {
+		since, err := ghub.ParseSince(value, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !since.Equal(expected) {
+			t.Fatalf("Expected %s for %q but got %s", expected, value, since)
+		}
+	}
+	_, err := ghub.ParseSince("yesterday", now)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid date")
+	}
+}
+
+func TestHumanizeTime(t *testing.T) {
+	now := time.Date(2018, time.October, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Hour), "1 hour ago"},
+		{now.Add(-72 * time.Hour), "3 days ago"},
+		{now.Add(-400 * 24 * time.Hour), "1 year ago"},
+	}
+	for _, test := range tests {
+		humanized := ghub.HumanizeTime(test.t, now)
+		if humanized != test.expected {
+			t.Fatalf("Expected %q but got %q", test.expected, humanized)
+		}
+	}
+}
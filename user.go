@@ -19,23 +19,12 @@ package ghub
 
 import (
 	"context"
-	"os"
 
 	"github.com/google/go-github/github"
-	"golang.org/x/oauth2"
 )
 
 // GetAuthenticatedUser ...
-func GetAuthenticatedUser() (*github.User, error) {
-	ctx := context.Background()
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: githubToken,
-		},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+func GetAuthenticatedUser(ctx context.Context, client *github.Client) (*github.User, error) {
 	user, _, err := client.Users.Get(ctx, "")
 	if err != nil {
 		return nil, err
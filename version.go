@@ -19,6 +19,12 @@ package ghub
 
 import "fmt"
 
+// VersionInfo ...
+type VersionInfo struct {
+	Version string `json:"version" yaml:"version"`
+	Build   string `json:"build" yaml:"build"`
+}
+
 // ShowVersionInfo returns version and build information
 func ShowVersionInfo(version, build string) string {
 	tpl := "version %s build %s\n"
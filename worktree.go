@@ -0,0 +1,147 @@
+// Copyright 2018 Raül Pérez, repejota@gmail.com. All rights reserved.
+//
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with this
+// work for additional information regarding copyright ownership.  The ASF
+// licenses this file to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ghub
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/repejota/git-hub/automation"
+)
+
+// WorktreeReport is a working tree with the state of the issue and pull
+// request of its branch
+type WorktreeReport struct {
+	Path        string `json:"path" yaml:"path"`
+	Branch      string `json:"branch" yaml:"branch"`
+	Issue       string `json:"issue" yaml:"issue"`
+	PullRequest string `json:"pull_request" yaml:"pull_request"`
+	// Reason is why the working tree can be removed, empty if it can not
+	Reason string `json:"reason" yaml:"reason"`
+	// Main is true for the working tree of the repository itself
+	Main bool `json:"-" yaml:"-"`
+}
+
+// Highlighted highlights the working trees that can be removed
+func (w *WorktreeReport) Highlighted() bool {
+	return w.Reason != ""
+}
+
+// WorktreeDirectory returns the directory issue working trees are added to,
+// the git-hub.worktreeDirectory setting, relative to the repository, or a
+// directory next to the repository named after it
+func WorktreeDirectory() (string, error) {
+	commonDir, err := automation.GetCommonDir()
+	if err != nil {
+		return "", err
+	}
+	repositoryPath := filepath.Dir(commonDir)
+
+	directory, err := automation.GetConfig("worktreeDirectory")
+	if err != nil {
+		return "", err
+	}
+	if directory == "" {
+		return filepath.Join(filepath.Dir(repositoryPath), filepath.Base(repositoryPath)+"-worktrees"), nil
+	}
+	if !filepath.IsAbs(directory) {
+		directory = filepath.Join(repositoryPath, directory)
+	}
+	return directory, nil
+}
+
+// AddIssueWorktree returns the working tree of an issue branch, adding it if
+// there is none, named after the issue. New branches are created from the
+// start point.
+func AddIssueWorktree(issue *github.Issue, branchName string, startPoint string) (string, error) {
+	worktrees, err := automation.ListWorktrees()
+	if err != nil {
+		return "", err
+	}
+	for _, worktree := range worktrees {
+		if worktree.Branch == branchName {
+			return worktree.Path, nil
+		}
+	}
+
+	directory, err := WorktreeDirectory()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(directory, SlugifyIssue(issue))
+	_, err = automation.AddWorktree(path, branchName, startPoint)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListWorktrees returns the working trees of a repository with the issue and
+// pull request states of their branches. Working trees other than the main
+// one can be removed when their issue is closed or their branch is merged.
+func ListWorktrees(ctx context.Context, client *github.Client, repository *Repository) ([]*WorktreeReport, error) {
+	worktrees, err := automation.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	branches, err := repository.ListWorkflowBranches("feature/", "issue/")
+	if err != nil {
+		return nil, err
+	}
+	reports := map[string]*BranchReport{}
+	for _, branch := range branches {
+		reports[branch.Name] = branch
+	}
+
+	// Only the branches with a working tree are looked up on GitHub
+	worktreeBranches := []*BranchReport{}
+	for _, worktree := range worktrees {
+		if report, ok := reports[worktree.Branch]; ok {
+			worktreeBranches = append(worktreeBranches, report)
+		}
+	}
+	org, repo := ParseRepositoryFullName(repository.GitHubRepository.GetFullName())
+	err = AddBranchStates(ctx, client, org, repo, worktreeBranches)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := []*WorktreeReport{}
+	for i, worktree := range worktrees {
+		if worktree.Bare {
+			continue
+		}
+		worktreeReport := &WorktreeReport{
+			Path:   worktree.Path,
+			Branch: worktree.Branch,
+			Main:   i == 0,
+		}
+		if report, ok := reports[worktree.Branch]; ok {
+			worktreeReport.Issue = report.Issue
+			worktreeReport.PullRequest = report.PullRequest
+			if !worktreeReport.Main {
+				worktreeReport.Reason = report.PruneReason(0, now)
+			}
+		}
+		result = append(result, worktreeReport)
+	}
+	return result, nil
+}